@@ -0,0 +1,451 @@
+package georm
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+)
+
+var ErrCrossesAntimeridian = errors.New("geometry crosses the antimeridian")
+
+var (
+	// SplitAntimeridianOnWrite enables SplitAntimeridian of geometries in Value,
+	// geometries which type cannot hold split parts are rejected with ErrCrossesAntimeridian
+	SplitAntimeridianOnWrite = false
+
+	// SplitAntimeridianInGeoJSON enables SplitAntimeridian of geometries in MarshalJSON
+	// as recommended by RFC 7946 §3.1.9, split LineString and Polygon are encoded
+	// as MultiLineString and MultiPolygon
+	SplitAntimeridianInGeoJSON = false
+)
+
+// CrossesAntimeridian reports whether lon/lat lines or polygons cross the
+// 180th meridian. Consecutive points more than 180 degrees of longitude apart
+// and longitudes beyond ±180 are treated as crossing
+func (g Geometry[T]) CrossesAntimeridian() bool {
+	if isNil(g.Geom) {
+		return false
+	}
+
+	crosses := false
+
+	eachPart(g.Geom, func(part geom.T) {
+		switch part := part.(type) {
+		case *geom.LineString:
+			crosses = crosses || len(splitLine(part.FlatCoords(), part.Stride())) > 1
+		case *geom.Polygon:
+			if part.NumLinearRings() > 0 {
+				minX, maxX := lonRange(unwrapLon(part.LinearRing(0).FlatCoords(), part.Stride(), true), part.Stride())
+				lo, hi := lonBands(minX, maxX)
+				crosses = crosses || lo != hi
+			}
+		}
+	})
+
+	return crosses
+}
+
+// SplitAntimeridian splits lon/lat lines and polygons crossing the 180th
+// meridian into parts on both sides of it (RFC 7946 §3.1.9), longitudes of
+// all points are normalized to [-180, 180]. LineString and Polygon split
+// into several parts can only be returned as Geometry[geom.T] or Multi* type,
+// split polygons are XY.
+//
+// Lines take the shorter way between points, polygons must not contain a pole
+func (g Geometry[T]) SplitAntimeridian() (Geometry[T], error) {
+	if err := checkGeographic(g.Geom); err != nil {
+		return g, err
+	}
+
+	if isNil(g.Geom) {
+		return g, nil
+	}
+
+	split := splitAntimeridian(g.Geom)
+
+	result, ok := split.(T)
+	if !ok {
+		return g, fmt.Errorf("%w: result is %T", ErrCrossesAntimeridian, split)
+	}
+
+	return Geometry[T]{result}, nil
+}
+
+// WrappedBounds returns 2D bounding box of lon/lat geometry by the shortest
+// range of longitudes, MinX is greater than MaxX when the range crosses the
+// antimeridian (RFC 7946 §5.2). Geometry covering all longitudes gets MinX -180
+// and MaxX 180, zero box is returned for empty geometry
+func (g Geometry[T]) WrappedBounds() Box2D {
+	bounds := g.Bounds()
+	if isNil(g.Geom) || g.Geom.Empty() {
+		return bounds
+	}
+
+	// longitude ranges covered by points and edges, ranges crossing the
+	// antimeridian are split, so that -180 and 180 are the same longitude
+	var ranges [][2]float64
+
+	cover := func(flatCoords []float64, stride int, ring bool) {
+		unwrapped := unwrapLon(flatCoords, stride, ring)
+
+		for i := 0; i < len(unwrapped); i += stride {
+			from, to := unwrapped[max(i-stride, 0)], unwrapped[i]
+			if from > to {
+				from, to = to, from
+			}
+
+			if to-from >= 360 {
+				ranges = append(ranges, [2]float64{-180, 180})
+				continue
+			}
+
+			shift := normalizeLon(from) - from
+			if from, to = from+shift, to+shift; to > 180 {
+				ranges = append(ranges, [2]float64{from, 180}, [2]float64{-180, to - 360})
+			} else {
+				ranges = append(ranges, [2]float64{from, to})
+			}
+		}
+	}
+
+	eachPart(g.Geom, func(part geom.T) {
+		switch part := part.(type) {
+		case *geom.LineString:
+			cover(part.FlatCoords(), part.Stride(), false)
+		case *geom.Polygon:
+			for i := 0; i < part.NumLinearRings(); i++ {
+				cover(part.LinearRing(i).FlatCoords(), part.Stride(), true)
+			}
+		default:
+			for i := 0; i < len(part.FlatCoords()); i += part.Stride() {
+				lon := normalizeLon(part.FlatCoords()[i])
+				ranges = append(ranges, [2]float64{lon, lon})
+			}
+		}
+	})
+
+	slices.SortFunc(ranges, func(a, b [2]float64) int { return cmp.Compare(a[0], b[0]) })
+
+	// the box is the complement of the largest gap between covered ranges,
+	// there is no gap when ranges cover all longitudes
+	var (
+		gap float64
+		end = ranges[0][1]
+	)
+
+	bounds.MinX, bounds.MaxX = -180, 180
+
+	for i := 1; i < len(ranges); i++ {
+		if d := ranges[i][0] - end; d > gap {
+			gap, bounds.MinX, bounds.MaxX = d, ranges[i][0], end
+		}
+
+		end = max(end, ranges[i][1])
+	}
+
+	if d := ranges[0][0] + 360 - end; d > gap {
+		bounds.MinX, bounds.MaxX = ranges[0][0], end
+	}
+
+	// the box ending at the antimeridian does not cross it
+	switch {
+	case bounds.MinX > bounds.MaxX && bounds.MinX == 180:
+		bounds.MinX = -180
+	case bounds.MinX > bounds.MaxX && bounds.MaxX == -180:
+		bounds.MaxX = 180
+	}
+
+	return bounds
+}
+
+// CrossesAntimeridian reports whether the box wraps over the antimeridian, MinX > MaxX
+func (b Box2D) CrossesAntimeridian() bool {
+	return b.MinX > b.MaxX
+}
+
+// SplitAntimeridian returns boxes on both sides of the antimeridian for the
+// box crossing it, otherwise the box itself
+func (b Box2D) SplitAntimeridian() []Box2D {
+	if !b.CrossesAntimeridian() {
+		return []Box2D{b}
+	}
+
+	return []Box2D{
+		{MinX: b.MinX, MinY: b.MinY, MaxX: 180, MaxY: b.MaxY},
+		{MinX: -180, MinY: b.MinY, MaxX: b.MaxX, MaxY: b.MaxY},
+	}
+}
+
+func splitAntimeridian(g geom.T) geom.T {
+	layout := g.Layout()
+
+	switch g := g.(type) {
+	case *geom.Point:
+		return geom.NewPointFlat(layout, normalizeLons(g.FlatCoords(), g.Stride())).SetSRID(g.SRID())
+	case *geom.MultiPoint:
+		return geom.NewMultiPointFlat(layout, normalizeLons(g.FlatCoords(), g.Stride())).SetSRID(g.SRID())
+	case *geom.LineString:
+		lines := splitLine(g.FlatCoords(), g.Stride())
+		if len(lines) == 1 {
+			return geom.NewLineStringFlat(layout, lines[0]).SetSRID(g.SRID())
+		}
+
+		return newMultiLineString(layout, g.SRID(), lines)
+	case *geom.MultiLineString:
+		var lines [][]float64
+
+		for i := 0; i < g.NumLineStrings(); i++ {
+			lines = append(lines, splitLine(g.LineString(i).FlatCoords(), g.Stride())...)
+		}
+
+		return newMultiLineString(layout, g.SRID(), lines)
+	case *geom.Polygon:
+		polygons := splitPolygon(g)
+
+		switch len(polygons) {
+		case 0:
+			return geom.NewPolygon(geom.XY).SetSRID(g.SRID())
+		case 1:
+			return polygons[0].SetSRID(g.SRID())
+		}
+
+		return newMultiPolygon(polygons[0].Layout(), g.SRID(), polygons)
+	case *geom.MultiPolygon:
+		var polygons []*geom.Polygon
+
+		for i := 0; i < g.NumPolygons(); i++ {
+			polygons = append(polygons, splitPolygon(g.Polygon(i))...)
+		}
+
+		// split parts are XY, so are other polygons
+		if slices.ContainsFunc(polygons, func(p *geom.Polygon) bool { return p.Layout() != layout }) {
+			for i, p := range polygons {
+				polygons[i] = polygonXY(p)
+			}
+
+			layout = geom.XY
+		}
+
+		return newMultiPolygon(layout, g.SRID(), polygons)
+	case *geom.GeometryCollection:
+		gc := geom.NewGeometryCollection().SetSRID(g.SRID())
+
+		for _, child := range g.Geoms() {
+			_ = gc.Push(splitAntimeridian(child))
+		}
+
+		return gc
+	default:
+		return g
+	}
+}
+
+func polygonXY(p *geom.Polygon) *geom.Polygon {
+	if p.Layout() == geom.XY {
+		return p
+	}
+
+	ends := make([]int, len(p.Ends()))
+	for i, end := range p.Ends() {
+		ends[i] = end / p.Stride() * 2
+	}
+
+	return geom.NewPolygonFlat(geom.XY, xyCoords(p.FlatCoords(), p.Stride()), ends)
+}
+
+func newMultiLineString(layout geom.Layout, srid int, lines [][]float64) *geom.MultiLineString {
+	mls := geom.NewMultiLineString(layout).SetSRID(srid)
+
+	for _, line := range lines {
+		_ = mls.Push(geom.NewLineStringFlat(layout, line))
+	}
+
+	return mls
+}
+
+// normalizeLon returns longitude in [-180, 180]
+func normalizeLon(lon float64) float64 {
+	if lon >= -180 && lon <= 180 {
+		return lon
+	}
+
+	return lon - 360*math.Round(lon/360)
+}
+
+func normalizeLons(flatCoords []float64, stride int) []float64 {
+	result := slices.Clone(flatCoords)
+	for i := 0; i < len(result); i += stride {
+		result[i] = normalizeLon(result[i])
+	}
+
+	return result
+}
+
+// unwrapLon returns coordinates with longitudes changed by multiples of 360
+// so that consecutive points are at most 180 degrees of longitude apart, points
+// exactly 180 degrees apart keep the direction. Edges of rings between points
+// on the antimeridian are kept as is, so they may go along the whole parallel
+func unwrapLon(flatCoords []float64, stride int, ring bool) []float64 {
+	result := slices.Clone(flatCoords)
+
+	for i := stride; i < len(result); i += stride {
+		d := flatCoords[i] - flatCoords[i-stride]
+		if !ring || math.Abs(d) != 360 || math.Abs(flatCoords[i]) != 180 {
+			d -= 360 * math.RoundToEven(d/360)
+		}
+
+		result[i] = result[i-stride] + d
+	}
+
+	return result
+}
+
+func lonRange(flatCoords []float64, stride int) (float64, float64) {
+	minX, maxX := math.Inf(1), math.Inf(-1)
+
+	for i := 0; i < len(flatCoords); i += stride {
+		minX, maxX = math.Min(minX, flatCoords[i]), math.Max(maxX, flatCoords[i])
+	}
+
+	return minX, maxX
+}
+
+// lonBand returns the number of the 360 degrees band of unwrapped longitude,
+// band 0 is [-180, 180)
+func lonBand(lon float64) int {
+	return int(math.Floor((lon + 180) / 360))
+}
+
+// lonBands returns the first and the last band of the longitude range,
+// the range ending at the band boundary does not reach the next band
+func lonBands(minX, maxX float64) (int, int) {
+	lo, hi := lonBand(minX), int(math.Ceil((maxX+180)/360))-1
+
+	return lo, max(lo, hi)
+}
+
+// splitLine splits the line at the antimeridian, parts have normalized longitudes
+func splitLine(flatCoords []float64, stride int) [][]float64 {
+	unwrapped := unwrapLon(flatCoords, stride, false)
+	if len(unwrapped) < 2*stride {
+		return [][]float64{normalizeLons(unwrapped, stride)}
+	}
+
+	var (
+		lines [][]float64
+		line  []float64
+		band  int
+	)
+
+	flush := func() {
+		for i := 0; i < len(line); i += stride {
+			line[i] -= 360 * float64(band)
+		}
+
+		lines = append(lines, line)
+	}
+
+	appendSegment := func(a, b []float64) {
+		if segmentBand := lonBand((a[0] + b[0]) / 2); line == nil || segmentBand != band {
+			if line != nil {
+				flush()
+			}
+
+			line, band = slices.Clone(a), segmentBand
+		}
+
+		line = append(line, b...)
+	}
+
+	for i := stride; i < len(unwrapped); i += stride {
+		a, b := unwrapped[i-stride:i], unwrapped[i:i+stride]
+
+		// the band boundary at most 180 degrees apart points may cross
+		boundary := 180 + 360*math.Floor((math.Max(a[0], b[0])-180)/360)
+		if boundary <= math.Min(a[0], b[0]) || boundary >= math.Max(a[0], b[0]) {
+			appendSegment(a, b)
+			continue
+		}
+
+		t := (boundary - a[0]) / (b[0] - a[0])
+
+		at := make([]float64, stride)
+		for j := range at {
+			at[j] = a[j] + (b[j]-a[j])*t
+		}
+
+		at[0] = boundary
+
+		appendSegment(a, at)
+		appendSegment(at, b)
+	}
+
+	flush()
+
+	return lines
+}
+
+// splitPolygon clips the unwrapped polygon by 360 degrees bands of longitude
+func splitPolygon(p *geom.Polygon) []*geom.Polygon {
+	if p.NumLinearRings() == 0 {
+		return []*geom.Polygon{p}
+	}
+
+	var (
+		layout = p.Layout()
+		stride = p.Stride()
+		shell  = unwrapLon(p.LinearRing(0).FlatCoords(), stride, true)
+		ends   = []int{len(shell)}
+		flat   = slices.Clone(shell)
+	)
+
+	minX, maxX := lonRange(shell, stride)
+
+	// holes are shifted next to the shell
+	for i := 1; i < p.NumLinearRings(); i++ {
+		hole := unwrapLon(p.LinearRing(i).FlatCoords(), stride, true)
+
+		if shift := 360 * math.Round(((minX+maxX)/2-hole[0])/360); shift != 0 {
+			for j := 0; j < len(hole); j += stride {
+				hole[j] += shift
+			}
+		}
+
+		flat = append(flat, hole...)
+		ends = append(ends, len(flat))
+	}
+
+	unwrapped := geom.NewPolygonFlat(layout, flat, ends)
+
+	lo, hi := lonBands(minX, maxX)
+	if lo == hi {
+		for i := 0; i < len(flat); i += stride {
+			flat[i] -= 360 * float64(lo)
+		}
+
+		return []*geom.Polygon{unwrapped}
+	}
+
+	var polygons []*geom.Polygon
+
+	for band := lo; band <= hi; band++ {
+		west, east := float64(360*band-180), float64(360*band+180)
+
+		clip := geom.NewPolygonFlat(geom.XY, []float64{west, -90, east, -90, east, 90, west, 90, west, -90}, []int{10})
+
+		for _, part := range overlayPolygons([]*geom.Polygon{unwrapped}, []*geom.Polygon{clip}, func(inA, inB bool) bool { return inA && inB }) {
+			flatCoords := part.FlatCoords()
+			for i := 0; i < len(flatCoords); i += 2 {
+				flatCoords[i] -= 360 * float64(band)
+			}
+
+			polygons = append(polygons, part)
+		}
+	}
+
+	return polygons
+}
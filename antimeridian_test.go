@@ -0,0 +1,224 @@
+package georm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestGeometrySplitAntimeridian(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Geom    geom.T
+		Crosses bool
+		Expect  geom.T
+	}{
+		{
+			Name:    "point",
+			Geom:    geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{190, 10}).SetSRID(4326),
+			Crosses: false,
+			Expect:  geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{-170, 10}).SetSRID(4326),
+		},
+		{
+			Name:    "line string not crossing",
+			Geom:    geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{170, 0}, {175, 5}, {180, 10}}).SetSRID(4326),
+			Crosses: false,
+			Expect:  geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{170, 0}, {175, 5}, {180, 10}}).SetSRID(4326),
+		},
+		{
+			Name:    "line string with longitude jump",
+			Geom:    geom.NewLineString(geom.XYZ).MustSetCoords([]geom.Coord{{170, 0, 1}, {-170, 10, 3}, {-160, 10, 4}}).SetSRID(4326),
+			Crosses: true,
+			Expect: geom.NewMultiLineString(geom.XYZ).MustSetCoords([][]geom.Coord{
+				{{170, 0, 1}, {180, 5, 2}},
+				{{-180, 5, 2}, {-170, 10, 3}, {-160, 10, 4}},
+			}).SetSRID(4326),
+		},
+		{
+			Name:    "line string beyond 180",
+			Geom:    geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{-170, 0}, {-190, 10}}).SetSRID(4326),
+			Crosses: true,
+			Expect: geom.NewMultiLineString(geom.XY).MustSetCoords([][]geom.Coord{
+				{{-170, 0}, {-180, 5}},
+				{{180, 5}, {170, 10}},
+			}).SetSRID(4326),
+		},
+		{
+			Name:    "line string through the antimeridian vertex",
+			Geom:    geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{170, 0}, {180, 5}, {-170, 10}}).SetSRID(4326),
+			Crosses: true,
+			Expect: geom.NewMultiLineString(geom.XY).MustSetCoords([][]geom.Coord{
+				{{170, 0}, {180, 5}},
+				{{-180, 5}, {-170, 10}},
+			}).SetSRID(4326),
+		},
+		{
+			Name:    "polygon",
+			Geom:    geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{170, -10}, {-170, -10}, {-170, 10}, {170, 10}, {170, -10}}}).SetSRID(4326),
+			Crosses: true,
+			Expect: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{170, -10}, {180, -10}, {180, 10}, {170, 10}, {170, -10}}},
+				{{{-180, -10}, {-170, -10}, {-170, 10}, {-180, 10}, {-180, -10}}},
+			}).SetSRID(4326),
+		},
+		{
+			Name:    "world polygon",
+			Geom:    geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{-180, -90}, {180, -90}, {180, 90}, {-180, 90}, {-180, -90}}}).SetSRID(4326),
+			Crosses: false,
+			Expect:  geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{-180, -90}, {180, -90}, {180, 90}, {-180, 90}, {-180, -90}}}).SetSRID(4326),
+		},
+		{
+			Name:    "full-width band",
+			Geom:    geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{-180, 10}, {0, 10}, {180, 10}, {180, 20}, {0, 20}, {-180, 20}, {-180, 10}}}).SetSRID(4326),
+			Crosses: false,
+			Expect:  geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{-180, 10}, {0, 10}, {180, 10}, {180, 20}, {0, 20}, {-180, 20}, {-180, 10}}}).SetSRID(4326),
+		},
+		{
+			Name:    "polygon beyond 180",
+			Geom:    geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{181, 0}, {182, 0}, {182, 1}, {181, 0}}}).SetSRID(4326),
+			Crosses: false,
+			Expect:  geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{-179, 0}, {-178, 0}, {-178, 1}, {-179, 0}}}).SetSRID(4326),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			g := New(test.Geom)
+
+			assert.Equal(t, test.Crosses, g.CrossesAntimeridian())
+
+			split, err := g.SplitAntimeridian()
+			require.NoError(t, err)
+
+			if _, ok := test.Expect.(*geom.MultiPolygon); ok {
+				assertSameArea(t, New(test.Expect), split)
+				return
+			}
+
+			assert.Equal(t, test.Expect, split.Geom)
+			assert.InDelta(t, g.Area(), split.Area(), 1e-9)
+		})
+	}
+}
+
+func TestGeometrySplitAntimeridianPolygonWithHole(t *testing.T) {
+	polygon := NewPolygon(
+		[]geom.Coord{{170, -10}, {-170, -10}, {-170, 10}, {170, 10}, {170, -10}},
+		[]geom.Coord{{-178, -5}, {178, -5}, {178, 5}, {-178, 5}, {-178, -5}},
+	)
+
+	split, err := New[geom.T](polygon.Geom).SplitAntimeridian()
+	require.NoError(t, err)
+
+	mp, ok := split.Geom.(*geom.MultiPolygon)
+	require.True(t, ok)
+	require.Equal(t, 2, mp.NumPolygons())
+
+	assert.InDelta(t, 400-40, split.Area(), 1e-9)
+	assert.True(t, split.IsValid())
+
+	bounds := split.WrappedBounds()
+	assert.Equal(t, Box2D{MinX: 170, MinY: -10, MaxX: -170, MaxY: 10}, bounds)
+}
+
+func TestGeometrySplitAntimeridianErrors(t *testing.T) {
+	line := NewLineString(geom.Coord{170, 0}, geom.Coord{-170, 10})
+
+	_, err := line.SplitAntimeridian()
+	require.ErrorIs(t, err, ErrCrossesAntimeridian)
+
+	projected := New(geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{170, 0}, {-170, 10}}).SetSRID(3857))
+
+	_, err = projected.SplitAntimeridian()
+	require.ErrorIs(t, err, ErrNotGeographic)
+}
+
+func TestGeometryWrappedBounds(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Geom   geom.T
+		Expect Box2D
+	}{
+		{
+			Name:   "empty",
+			Geom:   geom.NewLineString(geom.XY),
+			Expect: Box2D{},
+		},
+		{
+			Name:   "not crossing",
+			Geom:   geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{10, 0}, {-10, 5}}),
+			Expect: Box2D{MinX: -10, MinY: 0, MaxX: 10, MaxY: 5},
+		},
+		{
+			Name:   "crossing",
+			Geom:   geom.NewMultiPoint(geom.XY).MustSetCoords([]geom.Coord{{170, 0}, {-170, 5}, {175, 1}}),
+			Expect: Box2D{MinX: 170, MinY: 0, MaxX: -170, MaxY: 5},
+		},
+		{
+			Name:   "beyond 180",
+			Geom:   geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{175, 0}, {185, 5}}),
+			Expect: Box2D{MinX: 175, MinY: 0, MaxX: -175, MaxY: 5},
+		},
+		{
+			Name:   "point on the antimeridian",
+			Geom:   geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{-180, 5}),
+			Expect: Box2D{MinX: -180, MinY: 5, MaxX: -180, MaxY: 5},
+		},
+		{
+			Name:   "world polygon",
+			Geom:   geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{-180, -90}, {180, -90}, {180, 90}, {-180, 90}, {-180, -90}}}),
+			Expect: Box2D{MinX: -180, MinY: -90, MaxX: 180, MaxY: 90},
+		},
+		{
+			Name:   "full-width band",
+			Geom:   geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{-180, 10}, {0, 10}, {180, 10}, {180, 20}, {0, 20}, {-180, 20}, {-180, 10}}}),
+			Expect: Box2D{MinX: -180, MinY: 10, MaxX: 180, MaxY: 20},
+		},
+		{
+			Name:   "points on both sides of the antimeridian",
+			Geom:   geom.NewMultiPoint(geom.XY).MustSetCoords([]geom.Coord{{180, 0}, {-180, 5}, {170, 1}}),
+			Expect: Box2D{MinX: 170, MinY: 0, MaxX: 180, MaxY: 5},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			bounds := New(test.Geom).WrappedBounds()
+
+			assert.Equal(t, test.Expect, bounds)
+			assert.Equal(t, test.Expect.MinX > test.Expect.MaxX, bounds.CrossesAntimeridian())
+		})
+	}
+}
+
+func TestBox2DSplitAntimeridian(t *testing.T) {
+	assert.Equal(t, []Box2D{{MinX: 1, MinY: 2, MaxX: 3, MaxY: 4}}, Box2D{MinX: 1, MinY: 2, MaxX: 3, MaxY: 4}.SplitAntimeridian())
+	assert.Equal(t, []Box2D{
+		{MinX: 170, MinY: 2, MaxX: 180, MaxY: 4},
+		{MinX: -180, MinY: 2, MaxX: -170, MaxY: 4},
+	}, Box2D{MinX: 170, MinY: 2, MaxX: -170, MaxY: 4}.SplitAntimeridian())
+}
+
+func TestSplitAntimeridianOnWrite(t *testing.T) {
+	SplitAntimeridianOnWrite, SplitAntimeridianInGeoJSON = true, true
+	defer func() { SplitAntimeridianOnWrite, SplitAntimeridianInGeoJSON = false, false }()
+
+	route := New[geom.T](NewLineString(geom.Coord{170, 0}, geom.Coord{-170, 10}).Geom)
+
+	value, err := route.Value()
+	require.NoError(t, err)
+
+	var scanned MultiLineString
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, 2, scanned.Geom.NumLineStrings())
+
+	data, err := json.Marshal(route)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"MultiLineString","coordinates":[[[170,0],[180,5]],[[-180,5],[-170,10]]]}`, string(data))
+
+	_, err = NewLineString(geom.Coord{170, 0}, geom.Coord{-170, 10}).Value()
+	require.ErrorIs(t, err, ErrCrossesAntimeridian)
+}
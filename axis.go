@@ -0,0 +1,88 @@
+package georm
+
+import (
+	"github.com/twpayne/go-geom"
+)
+
+// AxisOrder is the order of the first two ordinates in decoded input
+type AxisOrder int
+
+const (
+	// AxisXY is x, y (lon, lat) order used by PostGIS, GeoJSON and WKT
+	AxisXY AxisOrder = iota
+
+	// AxisYX is y, x (lat, lon) order, coordinates are swapped while decoding
+	AxisYX
+)
+
+// PointLatLon returns point with the default SRID from latitude and longitude
+func PointLatLon(lat, lon float64) Point {
+	return NewPoint(lon, lat)
+}
+
+// SwapXY returns geometry with swapped x and y of all coordinates
+func (g Geometry[T]) SwapXY() Geometry[T] {
+	if isNil(g.Geom) {
+		return g
+	}
+
+	swapped, ok := swapXY(g.Geom).(T)
+	if !ok {
+		return g
+	}
+
+	return Geometry[T]{swapped}
+}
+
+func swapXY(g geom.T) geom.T {
+	if gc, ok := g.(*geom.GeometryCollection); ok {
+		swapped := geom.NewGeometryCollection().SetSRID(gc.SRID())
+
+		for _, child := range gc.Geoms() {
+			_ = swapped.Push(swapXY(child))
+		}
+
+		return swapped
+	}
+
+	swapped := cloneGeometry(g)
+	if swapped == g {
+		return g
+	}
+
+	// clone owns its flat coordinates
+	flatCoords, stride := swapped.FlatCoords(), swapped.Stride()
+	for i := 0; i < len(flatCoords); i += stride {
+		flatCoords[i], flatCoords[i+1] = flatCoords[i+1], flatCoords[i]
+	}
+
+	return swapped
+}
+
+// cloneGeometry returns a deep copy of geometry
+func cloneGeometry(g geom.T) geom.T {
+	switch g := g.(type) {
+	case *geom.Point:
+		return g.Clone()
+	case *geom.LineString:
+		return g.Clone()
+	case *geom.Polygon:
+		return g.Clone()
+	case *geom.MultiPoint:
+		return g.Clone()
+	case *geom.MultiLineString:
+		return g.Clone()
+	case *geom.MultiPolygon:
+		return g.Clone()
+	case *geom.GeometryCollection:
+		gc := geom.NewGeometryCollection().SetSRID(g.SRID())
+
+		for _, child := range g.Geoms() {
+			_ = gc.Push(cloneGeometry(child))
+		}
+
+		return gc
+	default:
+		return g
+	}
+}
@@ -0,0 +1,141 @@
+package georm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestPointLatLon(t *testing.T) {
+	p := PointLatLon(55.7558, 37.6173)
+
+	assert.Equal(t, geom.Coord{37.6173, 55.7558}, p.Geom.Coords())
+	assert.Equal(t, SRID, p.Geom.SRID())
+}
+
+func TestGeometrySwapXY(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Geom   geom.T
+		Expect geom.T
+	}{
+		{
+			Name:   "nil",
+			Geom:   nil,
+			Expect: nil,
+		},
+		{
+			Name:   "point",
+			Geom:   geom.NewPoint(geom.XYZ).MustSetCoords(geom.Coord{1, 2, 3}).SetSRID(4326),
+			Expect: geom.NewPoint(geom.XYZ).MustSetCoords(geom.Coord{2, 1, 3}).SetSRID(4326),
+		},
+		{
+			Name:   "line string",
+			Geom:   geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{1, 2}, {3, 4}}),
+			Expect: geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{2, 1}, {4, 3}}),
+		},
+		{
+			Name:   "polygon",
+			Geom:   geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 2}, {0, 0}}}),
+			Expect: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {0, 1}, {2, 1}, {0, 0}}}),
+		},
+		{
+			Name:   "multi point",
+			Geom:   geom.NewMultiPoint(geom.XY).MustSetCoords([]geom.Coord{{1, 2}, {3, 4}}),
+			Expect: geom.NewMultiPoint(geom.XY).MustSetCoords([]geom.Coord{{2, 1}, {4, 3}}),
+		},
+		{
+			Name:   "multi line string",
+			Geom:   geom.NewMultiLineString(geom.XY).MustSetCoords([][]geom.Coord{{{1, 2}, {3, 4}}}),
+			Expect: geom.NewMultiLineString(geom.XY).MustSetCoords([][]geom.Coord{{{2, 1}, {4, 3}}}),
+		},
+		{
+			Name:   "multi polygon",
+			Geom:   geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{{{{0, 0}, {1, 0}, {1, 2}, {0, 0}}}}),
+			Expect: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{{{{0, 0}, {0, 1}, {2, 1}, {0, 0}}}}),
+		},
+		{
+			Name:   "geometry collection",
+			Geom:   geom.NewGeometryCollection().MustPush(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 2})),
+			Expect: geom.NewGeometryCollection().MustPush(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{2, 1})),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			original := New(test.Geom)
+
+			assert.Equal(t, test.Expect, original.SwapXY().Geom)
+
+			// source geometry is not modified
+			if test.Geom != nil {
+				assert.Equal(t, test.Geom, original.SwapXY().SwapXY().Geom)
+			}
+		})
+	}
+}
+
+func TestGeometryUnmarshalGeoJSON(t *testing.T) {
+	var p Point
+
+	require.NoError(t, p.UnmarshalGeoJSON([]byte(`{"type":"Point","coordinates":[55.7558,150]}`), AxisYX))
+	assert.Equal(t, geom.Coord{150, 55.7558}, p.Geom.Coords())
+	assert.Equal(t, GeoJSONSRID, p.Geom.SRID())
+
+	// latitude out of range unless swapped
+	require.ErrorIs(t, p.UnmarshalGeoJSON([]byte(`{"type":"Point","coordinates":[55.7558,150]}`), AxisXY), ErrInvalidCoordinate)
+}
+
+func TestGeometryUnmarshalWKT(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Input  string
+		Order  AxisOrder
+		Expect geom.T
+		Error  bool
+	}{
+		{
+			Name:   "wkt",
+			Input:  "LINESTRING (1 2, 3 4)",
+			Expect: geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{1, 2}, {3, 4}}).SetSRID(4326),
+		},
+		{
+			Name:   "wkt lat lon",
+			Input:  "LINESTRING (1 2, 3 4)",
+			Order:  AxisYX,
+			Expect: geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{2, 1}, {4, 3}}).SetSRID(4326),
+		},
+		{
+			Name:   "ewkt",
+			Input:  "SRID=3857;LINESTRING (1000 2000, 3000 4000)",
+			Expect: geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{1000, 2000}, {3000, 4000}}).SetSRID(3857),
+		},
+		{
+			Name:  "invalid srid",
+			Input: "SRID=abc;LINESTRING (1 2, 3 4)",
+			Error: true,
+		},
+		{
+			Name:  "unexpected type",
+			Input: "POINT (1 2)",
+			Error: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var g LineString
+
+			err := g.UnmarshalWKT(test.Input, test.Order)
+			if test.Error {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.Expect, g.Geom)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package georm
+
+import (
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// InBBox returns condition matching rows which geometry bounding box
+// intersects the box, e.g. rows visible in the map viewport:
+//
+//	db.Where(georm.InBBox("geo_point", 11, 11, 15, 15, 4326)).Find(&addresses)
+//
+// The && operator is used so the spatial index of the column is applied.
+// Box coordinates are transformed to SRID of the column declared in the model
+// (GormDataType of the field or `gorm:"type:..."` tag) or to SRID for columns
+// of unknown type when srid differs from it. Box of geographic srid with
+// minX > maxX crosses the antimeridian and matches both sides of it
+func InBBox(field string, minX, minY, maxX, maxY float64, srid int) clause.Expression {
+	return inBBox{field: field, box: Box2D{MinX: minX, MinY: minY, MaxX: maxX, MaxY: maxY}, srid: srid}
+}
+
+type inBBox struct {
+	field string
+	box   Box2D
+	srid  int
+}
+
+// Build impl clause.Expression
+func (b inBBox) Build(builder clause.Builder) {
+	b.expression(builder).Build(builder)
+}
+
+func (b inBBox) expression(builder clause.Builder) clause.Expression {
+	geography, columnSRID, ok := columnType(builder, b.field)
+	if !ok {
+		columnSRID = SRID
+	}
+
+	envelope := "ST_MakeEnvelope(?, ?, ?, ?, ?)"
+	if b.srid != columnSRID {
+		envelope = "ST_Transform(" + envelope + ", " + strconv.Itoa(columnSRID) + ")"
+	}
+
+	if geography {
+		envelope += "::geography"
+	}
+
+	boxes := []Box2D{b.box}
+	if GeographicSRIDs[b.srid] {
+		boxes = b.box.SplitAntimeridian()
+	}
+
+	var (
+		conditions []string
+		vars       []any
+	)
+
+	for _, box := range boxes {
+		conditions = append(conditions, "? && "+envelope)
+		vars = append(vars, clause.Column{Name: b.field}, box.MinX, box.MinY, box.MaxX, box.MaxY, b.srid)
+	}
+
+	if len(conditions) == 1 {
+		return clause.Expr{SQL: conditions[0], Vars: vars}
+	}
+
+	return clause.Expr{SQL: "(" + strings.Join(conditions, " OR ") + ")", Vars: vars}
+}
@@ -0,0 +1,90 @@
+package georm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type testAddress struct {
+	ID       uint `gorm:"primaryKey"`
+	GeoPoint Point
+	Mercator Point `gorm:"type:geometry(Point,3857)"`
+	Location Point `gorm:"type:geography(Point,4326)"`
+}
+
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestInBBox(t *testing.T) {
+	db := dryRunDB(t)
+
+	tests := []struct {
+		Name   string
+		Query  func(tx *gorm.DB) *gorm.DB
+		Expect string
+	}{
+		{
+			Name: "same srid",
+			Query: func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(InBBox("geo_point", 11, 11, 15, 15.5, 4326)).Find(&[]testAddress{})
+			},
+			Expect: `SELECT * FROM "test_addresses" WHERE "geo_point" && ST_MakeEnvelope(11, 11, 15, 15.5, 4326)`,
+		},
+		{
+			Name: "transformed",
+			Query: func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(InBBox("test_addresses.geo_point", 0, 0, 1000, 1000, 3857)).Find(&[]testAddress{})
+			},
+			Expect: `SELECT * FROM "test_addresses" WHERE "test_addresses"."geo_point" && ST_Transform(ST_MakeEnvelope(0, 0, 1000, 1000, 3857), 4326)`,
+		},
+		{
+			Name: "column srid",
+			Query: func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(InBBox("mercator", 11, 11, 15, 15.5, 4326)).Find(&[]testAddress{})
+			},
+			Expect: `SELECT * FROM "test_addresses" WHERE "mercator" && ST_Transform(ST_MakeEnvelope(11, 11, 15, 15.5, 4326), 3857)`,
+		},
+		{
+			Name: "same column srid",
+			Query: func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(InBBox("test_addresses.mercator", 0, 0, 1000, 1000, 3857)).Find(&[]testAddress{})
+			},
+			Expect: `SELECT * FROM "test_addresses" WHERE "test_addresses"."mercator" && ST_MakeEnvelope(0, 0, 1000, 1000, 3857)`,
+		},
+		{
+			Name: "geography",
+			Query: func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(InBBox("location", 11, 11, 15, 15.5, 4326)).Find(&[]testAddress{})
+			},
+			Expect: `SELECT * FROM "test_addresses" WHERE "location" && ST_MakeEnvelope(11, 11, 15, 15.5, 4326)::geography`,
+		},
+		{
+			Name: "crossing antimeridian",
+			Query: func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(InBBox("geo_point", 170, -20, -170, 20, 4326)).Find(&[]testAddress{})
+			},
+			Expect: `SELECT * FROM "test_addresses" WHERE ("geo_point" && ST_MakeEnvelope(170, -20, 180, 20, 4326) OR "geo_point" && ST_MakeEnvelope(-180, -20, -170, 20, 4326))`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expect, db.ToSQL(test.Query))
+		})
+	}
+}
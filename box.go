@@ -0,0 +1,248 @@
+package georm
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/twpayne/go-geom"
+)
+
+var ErrInvalidBox = errors.New("invalid box")
+
+type (
+	// Box2D is a PostGIS box2d value, box3d values are scanned without z
+	Box2D struct {
+		MinX, MinY, MaxX, MaxY float64
+	}
+
+	// Box3D is a PostGIS box3d value, box2d values are scanned with zero z
+	Box3D struct {
+		MinX, MinY, MinZ, MaxX, MaxY, MaxZ float64
+	}
+)
+
+// NewBox2D creates box from bounds, bounds without coordinates give zero box
+func NewBox2D(bounds *geom.Bounds) Box2D {
+	if bounds == nil || bounds.IsEmpty() {
+		return Box2D{}
+	}
+
+	return Box2D{MinX: bounds.Min(0), MinY: bounds.Min(1), MaxX: bounds.Max(0), MaxY: bounds.Max(1)}
+}
+
+// NewBox3D creates box from bounds, z is zero for bounds without z
+func NewBox3D(bounds *geom.Bounds) Box3D {
+	if bounds == nil || bounds.IsEmpty() {
+		return Box3D{}
+	}
+
+	box := Box3D{MinX: bounds.Min(0), MinY: bounds.Min(1), MaxX: bounds.Max(0), MaxY: bounds.Max(1)}
+
+	if layout := bounds.Layout(); layout.ZIndex() != -1 {
+		box.MinZ, box.MaxZ = bounds.Min(layout.ZIndex()), bounds.Max(layout.ZIndex())
+	}
+
+	return box
+}
+
+// Bounds converts box to XY bounds
+func (b Box2D) Bounds() *geom.Bounds {
+	return geom.NewBounds(geom.XY).Set(b.MinX, b.MinY, b.MaxX, b.MaxY)
+}
+
+// Bounds converts box to XYZ bounds
+func (b Box3D) Bounds() *geom.Bounds {
+	return geom.NewBounds(geom.XYZ).Set(b.MinX, b.MinY, b.MinZ, b.MaxX, b.MaxY, b.MaxZ)
+}
+
+// Scan impl sql.Scanner, NULL is scanned as zero box
+func (b *Box2D) Scan(value interface{}) error {
+	coords, err := scanBox(value)
+	if err != nil || coords == nil {
+		*b = Box2D{}
+		return err
+	}
+
+	dim := len(coords) / 2
+	*b = Box2D{MinX: coords[0], MinY: coords[1], MaxX: coords[dim], MaxY: coords[dim+1]}
+
+	return nil
+}
+
+// Scan impl sql.Scanner, NULL is scanned as zero box
+func (b *Box3D) Scan(value interface{}) error {
+	coords, err := scanBox(value)
+	if err != nil || coords == nil {
+		*b = Box3D{}
+		return err
+	}
+
+	if len(coords) == 4 {
+		*b = Box3D{MinX: coords[0], MinY: coords[1], MaxX: coords[2], MaxY: coords[3]}
+		return nil
+	}
+
+	*b = Box3D{MinX: coords[0], MinY: coords[1], MinZ: coords[2], MaxX: coords[3], MaxY: coords[4], MaxZ: coords[5]}
+
+	return nil
+}
+
+// Value impl driver.Valuer, box is passed as box2d text, e.g. BOX(1 2,3 4)
+func (b Box2D) Value() (driver.Value, error) {
+	return b.String(), nil
+}
+
+// Value impl driver.Valuer, box is passed as box3d text, e.g. BOX3D(1 2 3,4 5 6)
+func (b Box3D) Value() (driver.Value, error) {
+	return b.String(), nil
+}
+
+// GormDataType impl schema.GormDataTypeInterface
+func (b Box2D) GormDataType() string {
+	return "box2d"
+}
+
+// GormDataType impl schema.GormDataTypeInterface
+func (b Box3D) GormDataType() string {
+	return "box3d"
+}
+
+// String returns box formatted as PostGIS box2d text
+func (b Box2D) String() string {
+	return "BOX(" + formatCoords(b.MinX, b.MinY) + "," + formatCoords(b.MaxX, b.MaxY) + ")"
+}
+
+// String returns box formatted as PostGIS box3d text
+func (b Box3D) String() string {
+	return "BOX3D(" + formatCoords(b.MinX, b.MinY, b.MinZ) + "," + formatCoords(b.MaxX, b.MaxY, b.MaxZ) + ")"
+}
+
+// MarshalJSON impl json.Marshaler, box is encoded as GeoJSON bbox: [minX, minY, maxX, maxY]
+func (b Box2D) MarshalJSON() ([]byte, error) {
+	return json.Marshal([4]float64{b.MinX, b.MinY, b.MaxX, b.MaxY})
+}
+
+// MarshalJSON impl json.Marshaler, box is encoded as GeoJSON bbox: [minX, minY, minZ, maxX, maxY, maxZ]
+func (b Box3D) MarshalJSON() ([]byte, error) {
+	return json.Marshal([6]float64{b.MinX, b.MinY, b.MinZ, b.MaxX, b.MaxY, b.MaxZ})
+}
+
+// UnmarshalJSON impl json.Unmarshaler, z values of 3D bbox are dropped
+func (b *Box2D) UnmarshalJSON(data []byte) error {
+	coords, err := unmarshalBBox(data)
+	if err != nil || coords == nil {
+		return err
+	}
+
+	dim := len(coords) / 2
+	*b = Box2D{MinX: coords[0], MinY: coords[1], MaxX: coords[dim], MaxY: coords[dim+1]}
+
+	return nil
+}
+
+// UnmarshalJSON impl json.Unmarshaler, z is zero for 2D bbox
+func (b *Box3D) UnmarshalJSON(data []byte) error {
+	coords, err := unmarshalBBox(data)
+	if err != nil || coords == nil {
+		return err
+	}
+
+	if len(coords) == 4 {
+		*b = Box3D{MinX: coords[0], MinY: coords[1], MaxX: coords[2], MaxY: coords[3]}
+		return nil
+	}
+
+	*b = Box3D{MinX: coords[0], MinY: coords[1], MinZ: coords[2], MaxX: coords[3], MaxY: coords[4], MaxZ: coords[5]}
+
+	return nil
+}
+
+// scanBox returns box coordinates of the scanned value, nil for NULL
+func scanBox(value interface{}) ([]float64, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return parseBox(v)
+	case []byte:
+		return parseBox(string(v))
+	default:
+		return nil, ErrUnexpectedValueType
+	}
+}
+
+// parseBox parses PostGIS box2d or box3d text: BOX(1 2,3 4), BOX3D(1 2 3,4 5 6)
+func parseBox(text string) ([]float64, error) {
+	var (
+		dim  int
+		body string
+		ok   bool
+	)
+
+	text = strings.TrimSpace(text)
+
+	if body, ok = strings.CutPrefix(text, "BOX3D("); ok {
+		dim = 3
+	} else if body, ok = strings.CutPrefix(text, "BOX("); ok {
+		dim = 2
+	} else {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBox, text)
+	}
+
+	body, ok = strings.CutSuffix(body, ")")
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBox, text)
+	}
+
+	corners := strings.Split(body, ",")
+	if len(corners) != 2 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBox, text)
+	}
+
+	coords := make([]float64, 0, 2*dim)
+
+	for _, corner := range corners {
+		values := strings.Fields(corner)
+		if len(values) != dim {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidBox, text)
+		}
+
+		for _, value := range values {
+			coord, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidBox, text)
+			}
+
+			coords = append(coords, coord)
+		}
+	}
+
+	return coords, nil
+}
+
+// unmarshalBBox decodes GeoJSON bbox array of 4 or 6 numbers, nil for null
+func unmarshalBBox(data []byte) ([]float64, error) {
+	var coords []float64
+	if err := json.Unmarshal(data, &coords); err != nil {
+		return nil, err
+	}
+
+	if coords != nil && len(coords) != 4 && len(coords) != 6 {
+		return nil, fmt.Errorf("%w: bbox of %d values", ErrInvalidBox, len(coords))
+	}
+
+	return coords, nil
+}
+
+func formatCoords(coords ...float64) string {
+	values := make([]string, len(coords))
+	for i, coord := range coords {
+		values[i] = strconv.FormatFloat(coord, 'f', -1, 64)
+	}
+
+	return strings.Join(values, " ")
+}
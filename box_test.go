@@ -0,0 +1,158 @@
+package georm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestBox2DScan(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Value  any
+		Expect Box2D
+		Error  error
+	}{
+		{Name: "box2d", Value: "BOX(1 2,3 4)", Expect: Box2D{MinX: 1, MinY: 2, MaxX: 3, MaxY: 4}},
+		{Name: "box2d bytes", Value: []byte("BOX(-1.5 2.25,3 4e2)"), Expect: Box2D{MinX: -1.5, MinY: 2.25, MaxX: 3, MaxY: 400}},
+		{Name: "box3d", Value: "BOX3D(1 2 3,4 5 6)", Expect: Box2D{MinX: 1, MinY: 2, MaxX: 4, MaxY: 5}},
+		{Name: "null", Value: nil, Expect: Box2D{}},
+		{Name: "unexpected value type", Value: 42, Error: ErrUnexpectedValueType},
+		{Name: "not a box", Value: "POINT(1 2)", Error: ErrInvalidBox},
+		{Name: "missing corner", Value: "BOX(1 2)", Error: ErrInvalidBox},
+		{Name: "wrong dimension", Value: "BOX(1 2 3,4 5 6)", Error: ErrInvalidBox},
+		{Name: "not a number", Value: "BOX(1 a,3 4)", Error: ErrInvalidBox},
+		{Name: "unclosed", Value: "BOX(1 2,3 4", Error: ErrInvalidBox},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			actual := Box2D{MinX: 42}
+
+			err := actual.Scan(test.Value)
+
+			if test.Error != nil {
+				assert.ErrorIs(t, err, test.Error)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.Expect, actual)
+		})
+	}
+}
+
+func TestBox2DValue(t *testing.T) {
+	value, err := Box2D{MinX: 1, MinY: -2.5, MaxX: 3, MaxY: 4}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "BOX(1 -2.5,3 4)", value)
+}
+
+func TestBox3DScan(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Value  any
+		Expect Box3D
+		Error  error
+	}{
+		{Name: "box3d", Value: "BOX3D(1 2 3,4 5 6)", Expect: Box3D{MinX: 1, MinY: 2, MinZ: 3, MaxX: 4, MaxY: 5, MaxZ: 6}},
+		{Name: "box3d bytes", Value: []byte("BOX3D(-1 -2 -3,4 5 6.5)"), Expect: Box3D{MinX: -1, MinY: -2, MinZ: -3, MaxX: 4, MaxY: 5, MaxZ: 6.5}},
+		{Name: "box2d", Value: "BOX(1 2,3 4)", Expect: Box3D{MinX: 1, MinY: 2, MaxX: 3, MaxY: 4}},
+		{Name: "null", Value: nil, Expect: Box3D{}},
+		{Name: "unexpected value type", Value: 42, Error: ErrUnexpectedValueType},
+		{Name: "wrong dimension", Value: "BOX3D(1 2,3 4)", Error: ErrInvalidBox},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			actual := Box3D{MinX: 42}
+
+			err := actual.Scan(test.Value)
+
+			if test.Error != nil {
+				assert.ErrorIs(t, err, test.Error)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.Expect, actual)
+		})
+	}
+}
+
+func TestBox3DValue(t *testing.T) {
+	value, err := Box3D{MinX: 1, MinY: -2.5, MinZ: 0, MaxX: 3, MaxY: 4, MaxZ: 10}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "BOX3D(1 -2.5 0,3 4 10)", value)
+}
+
+func TestBoxGormDataType(t *testing.T) {
+	assert.Equal(t, "box2d", Box2D{}.GormDataType())
+	assert.Equal(t, "box3d", Box3D{}.GormDataType())
+}
+
+func TestBoxBounds(t *testing.T) {
+	box2D := Box2D{MinX: 1, MinY: 2, MaxX: 3, MaxY: 4}
+	assert.Equal(t, geom.NewBounds(geom.XY).Set(1, 2, 3, 4), box2D.Bounds())
+	assert.Equal(t, box2D, NewBox2D(box2D.Bounds()))
+
+	box3D := Box3D{MinX: 1, MinY: 2, MinZ: 3, MaxX: 4, MaxY: 5, MaxZ: 6}
+	assert.Equal(t, geom.NewBounds(geom.XYZ).Set(1, 2, 3, 4, 5, 6), box3D.Bounds())
+	assert.Equal(t, box3D, NewBox3D(box3D.Bounds()))
+
+	polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{11, 11}, {11, 15}, {15, 15}, {15, 11}, {11, 11}}})
+	assert.Equal(t, Box2D{MinX: 11, MinY: 11, MaxX: 15, MaxY: 15}, NewBox2D(polygon.Bounds()))
+	assert.Equal(t, Box3D{MinX: 11, MinY: 11, MaxX: 15, MaxY: 15}, NewBox3D(polygon.Bounds()))
+
+	assert.Equal(t, Box2D{}, NewBox2D(geom.NewBounds(geom.XY)))
+	assert.Equal(t, Box3D{}, NewBox3D(nil))
+}
+
+func TestBoxJSON(t *testing.T) {
+	data, err := json.Marshal(Box2D{MinX: 1, MinY: 2, MaxX: 3, MaxY: 4.5})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[1,2,3,4.5]`, string(data))
+
+	data, err = json.Marshal(Box3D{MinX: 1, MinY: 2, MinZ: 3, MaxX: 4, MaxY: 5, MaxZ: 6})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[1,2,3,4,5,6]`, string(data))
+
+	tests := []struct {
+		Data     string
+		Expect2D Box2D
+		Expect3D Box3D
+		Error    bool
+	}{
+		{Data: `[1,2,3,4]`, Expect2D: Box2D{1, 2, 3, 4}, Expect3D: Box3D{MinX: 1, MinY: 2, MaxX: 3, MaxY: 4}},
+		{Data: `[1,2,3,4,5,6]`, Expect2D: Box2D{1, 2, 4, 5}, Expect3D: Box3D{1, 2, 3, 4, 5, 6}},
+		{Data: `null`},
+		{Data: `[1,2,3]`, Error: true},
+		{Data: `{"bbox":[1,2,3,4]}`, Error: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Data, func(t *testing.T) {
+			var (
+				box2D Box2D
+				box3D Box3D
+			)
+
+			err2D := json.Unmarshal([]byte(test.Data), &box2D)
+			err3D := json.Unmarshal([]byte(test.Data), &box3D)
+
+			if test.Error {
+				assert.Error(t, err2D)
+				assert.Error(t, err3D)
+				return
+			}
+
+			require.NoError(t, err2D)
+			require.NoError(t, err3D)
+			assert.Equal(t, test.Expect2D, box2D)
+			assert.Equal(t, test.Expect3D, box3D)
+		})
+	}
+}
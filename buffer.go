@@ -0,0 +1,182 @@
+package georm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/twpayne/go-geom"
+)
+
+// BufferQuadrantSegments is the number of segments approximating a quarter
+// of circle in Buffer, as quad_segs of PostGIS ST_Buffer
+var BufferQuadrantSegments = 8
+
+// Circle returns polygon of segments vertices lying on the geodesic circle
+// of radius in meters around the center on WGS 84 ellipsoid. Center must be
+// lon/lat of one of GeographicSRIDs, the polygon has the SRID of the center.
+// At least 3 segments are used. Longitudes of circles crossing the
+// antimeridian exceed 180 degrees, see SplitAntimeridian
+func Circle(center Point, radius float64, segments int) (Polygon, error) {
+	if err := checkGeographic(center.Geom); err != nil {
+		return Polygon{}, err
+	}
+
+	if isNil(center.Geom) {
+		return Polygon{}, nil
+	}
+
+	polygon := geom.NewPolygon(geom.XY).SetSRID(center.Geom.SRID())
+	if center.Geom.Empty() || radius <= 0 {
+		return New(polygon), nil
+	}
+
+	segments = max(segments, 3)
+
+	ring := make([]float64, 0, 2*(segments+1))
+
+	// decreasing azimuth goes counter-clockwise
+	for i := 0; i < segments; i++ {
+		ring = append(ring, geodesicDestination(center.Geom.FlatCoords(), -360*float64(i)/float64(segments), radius)...)
+	}
+
+	ring = append(ring, ring[0], ring[1])
+
+	return New(geom.NewPolygonFlat(geom.XY, ring, []int{len(ring)}).SetSRID(center.Geom.SRID())), nil
+}
+
+// Buffer returns planar polygon covering all points within the distance
+// of Point or LineString, distance is in units of coordinates. Circles are
+// approximated by 4*BufferQuadrantSegments segments, the polygon is XY
+// with the SRID of geometry. Empty polygon is returned for non-positive
+// distance as in PostGIS ST_Buffer, other geometry types are not supported
+func (g Geometry[T]) Buffer(distance float64) (Polygon, error) {
+	if isNil(g.Geom) {
+		return Polygon{}, nil
+	}
+
+	var (
+		srid    = g.Geom.SRID()
+		circle  = newBufferCircle(distance)
+		polygon *geom.Polygon
+	)
+
+	switch geometry := any(g.Geom).(type) {
+	case *geom.Point:
+		if geometry.Empty() || distance <= 0 {
+			break
+		}
+
+		polygon = circle.around(geometry.FlatCoords())
+	case *geom.LineString:
+		if geometry.Empty() || distance <= 0 {
+			break
+		}
+
+		polygon = circle.alongLine(xyCoords(geometry.FlatCoords(), geometry.Stride()))
+	default:
+		return Polygon{}, fmt.Errorf("%w: buffer of %T", ErrUnexpectedGeometryType, g.Geom)
+	}
+
+	if polygon == nil {
+		polygon = geom.NewPolygon(geom.XY)
+	}
+
+	return New(polygon.SetSRID(srid)), nil
+}
+
+// bufferCircle approximates circles of the buffer distance, vertices
+// of circles lie at the same angles to make arcs of neighbour circles
+// coincide
+type bufferCircle struct {
+	radius float64
+	n      int
+	step   float64
+}
+
+func newBufferCircle(radius float64) bufferCircle {
+	n := 4 * max(BufferQuadrantSegments, 1)
+	return bufferCircle{radius: radius, n: n, step: 2 * math.Pi / float64(n)}
+}
+
+func (c bufferCircle) at(center []float64, angle float64) []float64 {
+	sin, cos := math.Sincos(angle)
+	return []float64{center[0] + c.radius*cos, center[1] + c.radius*sin}
+}
+
+// arc appends vertices of the circle strictly between angles from and to
+func (c bufferCircle) arc(ring, center []float64, from, to float64) []float64 {
+	const eps = 1e-9
+
+	for k := int(math.Floor(from/c.step)) + 1; float64(k)*c.step < to-eps*c.step; k++ {
+		if float64(k)*c.step <= from+eps*c.step {
+			continue
+		}
+
+		// the same angle for every circle
+		ring = append(ring, c.at(center, float64((k%c.n+c.n)%c.n)*c.step)...)
+	}
+
+	return ring
+}
+
+func (c bufferCircle) around(center []float64) *geom.Polygon {
+	ring := c.at(center, 0)
+	ring = c.arc(ring, center, 0, 2*math.Pi)
+	ring = append(ring, ring[0], ring[1])
+
+	return geom.NewPolygonFlat(geom.XY, ring, []int{len(ring)})
+}
+
+// capsule returns convex polygon covering points within the radius of the segment
+func (c bufferCircle) capsule(start, end []float64) *geom.Polygon {
+	var (
+		angle = math.Atan2(end[1]-start[1], end[0]-start[0])
+		right = angle - math.Pi/2
+		left  = angle + math.Pi/2
+	)
+
+	ring := append(c.at(start, right), c.at(end, right)...)
+	ring = c.arc(ring, end, right, left)
+	ring = append(ring, c.at(end, left)...)
+	ring = append(ring, c.at(start, left)...)
+	ring = c.arc(ring, start, left, left+math.Pi)
+	ring = append(ring, ring[0], ring[1])
+
+	return geom.NewPolygonFlat(geom.XY, ring, []int{len(ring)})
+}
+
+// alongLine returns union of capsules of line segments
+func (c bufferCircle) alongLine(flatCoords []float64) *geom.Polygon {
+	var capsules []*geom.Polygon
+
+	for i := 2; i < len(flatCoords); i += 2 {
+		start, end := flatCoords[i-2:i], flatCoords[i:i+2]
+		if start[0] != end[0] || start[1] != end[1] {
+			capsules = append(capsules, c.capsule(start, end))
+		}
+	}
+
+	if len(capsules) == 0 {
+		return c.around(flatCoords[:2])
+	}
+
+	if len(capsules) == 1 {
+		return capsules[0]
+	}
+
+	polygons := overlayPolygons(capsules, nil, func(inA, _ bool) bool { return inA })
+
+	// the union of capsules is connected, parts split off by rounding are dropped
+	var (
+		result *geom.Polygon
+		area   float64
+	)
+
+	for _, p := range polygons {
+		if a := New(p).Area(); result == nil || a > area {
+			result, area = p, a
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,139 @@
+package georm
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestCircle(t *testing.T) {
+	center := NewPoint(37.6176, 55.7558)
+
+	circle, err := Circle(center, 1000, 64)
+	require.NoError(t, err)
+
+	require.Equal(t, 4326, circle.Geom.SRID())
+	require.Equal(t, 65, circle.Geom.NumCoords())
+	require.True(t, circle.IsValid())
+
+	for _, c := range circle.Coords() {
+		distance, err := NewPoint(c.X(), c.Y()).GeodesicDistance(center)
+		require.NoError(t, err)
+		assert.InDelta(t, 1000, distance, 1e-5)
+	}
+
+	area, err := circle.GeodesicArea()
+	require.NoError(t, err)
+
+	// area of regular polygon inscribed in the circle
+	assert.InEpsilon(t, 64*math.Sin(2*math.Pi/64)/2*1000*1000, area, 1e-4)
+	assert.True(t, circle.Contains(center))
+
+	empty, err := Circle(center, 0, 64)
+	require.NoError(t, err)
+	assert.True(t, empty.Geom.Empty())
+
+	triangle, err := Circle(center, 1000, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 4, triangle.Geom.NumCoords())
+
+	_, err = Circle(New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{0, 0}).SetSRID(3857)), 1000, 64)
+	require.ErrorIs(t, err, ErrNotGeographic)
+}
+
+func TestGeometryBuffer(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Geom     geom.T
+		Distance float64
+		Area     float64
+		Rings    int
+	}{
+		{
+			Name:     "point",
+			Geom:     geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 1}),
+			Distance: 1,
+			Area:     3.1214451522580537,
+			Rings:    1,
+		},
+		{
+			Name:     "segment",
+			Geom:     geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {10, 0}}),
+			Distance: 1,
+			Area:     20 + 3.1214451522580537,
+			Rings:    1,
+		},
+		{
+			Name:     "line string with turn",
+			Geom:     geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {10, 0}, {10, 10}}),
+			Distance: 1,
+			Area:     42.90180644032256,
+			Rings:    1,
+		},
+		{
+			Name:     "self-crossing line string makes a hole",
+			Geom:     geom.NewLineString(geom.XYZ).MustSetCoords([]geom.Coord{{0, 0, 1}, {10, 0, 1}, {10, 10, 1}, {5, -5, 1}}),
+			Distance: 1,
+			Area:     65.5474475855038,
+			Rings:    2,
+		},
+		{
+			Name:     "line string of a single point",
+			Geom:     geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{1, 1}, {1, 1}}),
+			Distance: 1,
+			Area:     3.1214451522580537,
+			Rings:    1,
+		},
+		{
+			Name:     "non-positive distance",
+			Geom:     geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {10, 0}}),
+			Distance: 0,
+			Area:     0,
+			Rings:    0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			buffer, err := New(test.Geom).Buffer(test.Distance)
+			require.NoError(t, err)
+
+			assert.InDelta(t, test.Area, buffer.Area(), 1e-9)
+			assert.Equal(t, test.Rings, buffer.Geom.NumLinearRings())
+			assert.Equal(t, geom.XY, buffer.Geom.Layout())
+			assert.True(t, buffer.IsValid())
+		})
+	}
+}
+
+func TestGeometryBufferDistance(t *testing.T) {
+	line := NewLineString(geom.Coord{0, 0}, geom.Coord{10, 0}, geom.Coord{10, 10}, geom.Coord{0, 10}, geom.Coord{5, 2})
+
+	buffer, err := line.Buffer(1)
+	require.NoError(t, err)
+
+	// circles are approximated by inscribed polygons
+	inner := math.Cos(math.Pi / float64(4*BufferQuadrantSegments))
+
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 1000; i++ {
+		p := NewPoint(r.Float64()*14-2, r.Float64()*14-2)
+
+		switch distance := line.Distance(p); {
+		case distance < inner:
+			assert.True(t, buffer.Covers(p), p.String())
+		case distance > 1:
+			assert.False(t, buffer.Intersects(p), p.String())
+		}
+	}
+}
+
+func TestGeometryBufferExpectErrors(t *testing.T) {
+	_, err := NewPolygon([]geom.Coord{{0, 0}, {1, 0}, {1, 1}, {0, 0}}).Buffer(1)
+	require.ErrorIs(t, err, ErrUnexpectedGeometryType)
+}
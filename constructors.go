@@ -0,0 +1,108 @@
+package georm
+
+import (
+	"github.com/twpayne/go-geom"
+)
+
+// NewPoint returns XY point with the default SRID
+func NewPoint(x, y float64) Point {
+	return New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{x, y}).SetSRID(SRID))
+}
+
+// NewLineString returns line string with the default SRID, layout is taken from
+// the first coordinate (XY, XYZ or XYZM). Panics when coordinates have different layouts
+func NewLineString(coords ...geom.Coord) LineString {
+	return New(geom.NewLineString(layoutOf(coords...)).MustSetCoords(coords).SetSRID(SRID))
+}
+
+// NewPolygon returns polygon with the default SRID from the shell and holes,
+// layout is taken from the first coordinate. Panics when coordinates have different layouts
+func NewPolygon(rings ...[]geom.Coord) Polygon {
+	var first []geom.Coord
+	if len(rings) > 0 {
+		first = rings[0]
+	}
+
+	return New(geom.NewPolygon(layoutOf(first...)).MustSetCoords(rings).SetSRID(SRID))
+}
+
+// NewMultiPolygon returns multi polygon with the default SRID from polygon rings,
+// layout is taken from the first coordinate. Panics when coordinates have different layouts
+func NewMultiPolygon(polygons ...[][]geom.Coord) MultiPolygon {
+	var first []geom.Coord
+	if len(polygons) > 0 && len(polygons[0]) > 0 {
+		first = polygons[0][0]
+	}
+
+	return New(geom.NewMultiPolygon(layoutOf(first...)).MustSetCoords(polygons).SetSRID(SRID))
+}
+
+// layoutOf returns layout by the first coordinate length, XY by default
+func layoutOf(coords ...geom.Coord) geom.Layout {
+	if len(coords) == 0 {
+		return geom.XY
+	}
+
+	switch len(coords[0]) {
+	case 3:
+		return geom.XYZ
+	case 4:
+		return geom.XYZM
+	default:
+		return geom.XY
+	}
+}
+
+// X returns x of Point, 0 for empty and other geometries
+func (g Geometry[T]) X() float64 {
+	if p, ok := any(g.Geom).(*geom.Point); ok && p != nil && !p.Empty() {
+		return p.X()
+	}
+
+	return 0
+}
+
+// Y returns y of Point, 0 for empty and other geometries
+func (g Geometry[T]) Y() float64 {
+	if p, ok := any(g.Geom).(*geom.Point); ok && p != nil && !p.Empty() {
+		return p.Y()
+	}
+
+	return 0
+}
+
+// Coords returns all coordinates of geometry in order: points of lines,
+// rings of polygons and parts of multi geometries and collections
+func (g Geometry[T]) Coords() []geom.Coord {
+	if isNil(g.Geom) {
+		return nil
+	}
+
+	return appendCoords(nil, g.Geom)
+}
+
+func appendCoords(coords []geom.Coord, g geom.T) []geom.Coord {
+	if collection, ok := g.(*geom.GeometryCollection); ok {
+		for _, child := range collection.Geoms() {
+			coords = appendCoords(coords, child)
+		}
+
+		return coords
+	}
+
+	flatCoords, stride := g.FlatCoords(), g.Stride()
+	for i := 0; i < len(flatCoords); i += stride {
+		coords = append(coords, geom.Coord(flatCoords[i:i+stride:i+stride]).Clone())
+	}
+
+	return coords
+}
+
+// Bounds returns 2D bounding box of geometry, zero box for empty geometry
+func (g Geometry[T]) Bounds() Box2D {
+	if isNil(g.Geom) {
+		return Box2D{}
+	}
+
+	return NewBox2D(g.Geom.Bounds())
+}
@@ -0,0 +1,99 @@
+package georm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twpayne/go-geom"
+)
+
+func TestConstructors(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Geom   geom.T
+		Expect geom.T
+	}{
+		{
+			Name:   "point",
+			Geom:   NewPoint(37.6173, 55.7558).Geom,
+			Expect: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{37.6173, 55.7558}).SetSRID(4326),
+		},
+		{
+			Name:   "line string",
+			Geom:   NewLineString(geom.Coord{0, 0}, geom.Coord{1, 1}).Geom,
+			Expect: geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}}).SetSRID(4326),
+		},
+		{
+			Name:   "line string xyz",
+			Geom:   NewLineString(geom.Coord{0, 0, 5}, geom.Coord{1, 1, 6}).Geom,
+			Expect: geom.NewLineString(geom.XYZ).MustSetCoords([]geom.Coord{{0, 0, 5}, {1, 1, 6}}).SetSRID(4326),
+		},
+		{
+			Name:   "empty line string",
+			Geom:   NewLineString().Geom,
+			Expect: geom.NewLineString(geom.XY).SetSRID(4326),
+		},
+		{
+			Name: "polygon",
+			Geom: NewPolygon(
+				[]geom.Coord{{0, 0}, {10, 0}, {10, 10}, {0, 0}},
+				[]geom.Coord{{5, 2}, {8, 2}, {8, 5}, {5, 2}},
+			).Geom,
+			Expect: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 0}},
+				{{5, 2}, {8, 2}, {8, 5}, {5, 2}},
+			}).SetSRID(4326),
+		},
+		{
+			Name: "multi polygon",
+			Geom: NewMultiPolygon(
+				[][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+				[][]geom.Coord{{{5, 5}, {6, 5}, {6, 6}, {5, 5}}},
+			).Geom,
+			Expect: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+				{{{5, 5}, {6, 5}, {6, 6}, {5, 5}}},
+			}).SetSRID(4326),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expect, test.Geom)
+		})
+	}
+}
+
+func TestConstructorsPanic(t *testing.T) {
+	assert.Panics(t, func() { NewLineString(geom.Coord{0, 0}, geom.Coord{1, 1, 1}) })
+}
+
+func TestGeometryAccessors(t *testing.T) {
+	p := NewPoint(1, 2)
+	assert.Equal(t, 1.0, p.X())
+	assert.Equal(t, 2.0, p.Y())
+	assert.Equal(t, []geom.Coord{{1, 2}}, p.Coords())
+	assert.Equal(t, Box2D{MinX: 1, MinY: 2, MaxX: 1, MaxY: 2}, p.Bounds())
+
+	var empty Point
+	assert.Equal(t, 0.0, empty.X())
+	assert.Nil(t, empty.Coords())
+	assert.Equal(t, Box2D{}, empty.Bounds())
+
+	polygon := NewPolygon([]geom.Coord{{0, 0}, {10, 0}, {10, 5}, {0, 0}})
+	assert.Equal(t, 0.0, polygon.X())
+	assert.Equal(t, []geom.Coord{{0, 0}, {10, 0}, {10, 5}, {0, 0}}, polygon.Coords())
+	assert.Equal(t, Box2D{MinX: 0, MinY: 0, MaxX: 10, MaxY: 5}, polygon.Bounds())
+
+	collection := New(geom.NewGeometryCollection().MustPush(
+		geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{-1, -1}),
+		geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{2, 3}, {4, 1}}),
+	))
+	assert.Equal(t, []geom.Coord{{-1, -1}, {2, 3}, {4, 1}}, collection.Coords())
+	assert.Equal(t, Box2D{MinX: -1, MinY: -1, MaxX: 4, MaxY: 3}, collection.Bounds())
+
+	// coords are copies
+	coords := polygon.Coords()
+	coords[0][0] = 42
+	assert.Equal(t, 0.0, polygon.Geom.Coords()[0][0][0])
+}
@@ -0,0 +1,108 @@
+package georm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/twpayne/go-geom"
+)
+
+var ErrInvalidCoordinate = errors.New("invalid coordinate")
+
+// GeographicSRIDs are SRIDs with longitude/latitude coordinates, longitudes
+// out of [-180, 180] and latitudes out of [-90, 90] are rejected for them
+// in Value and UnmarshalJSON. Empty map disables the range check
+var GeographicSRIDs = map[int]bool{
+	4326: true, // WGS 84
+	4258: true, // ETRS89
+	4269: true, // NAD83
+}
+
+// Coordinate problems
+const (
+	CoordinateNotFinite = "not finite"
+	LongitudeOutOfRange = "longitude out of range"
+	LatitudeOutOfRange  = "latitude out of range"
+)
+
+// CoordinateError describes a coordinate rejected by coordinate guards
+type CoordinateError struct {
+	// Index of the coordinate in the geometry, counting all points of all parts
+	Index  int
+	Coord  geom.Coord
+	Reason string
+}
+
+func (e *CoordinateError) Error() string {
+	return fmt.Sprintf("invalid coordinate #%d %v: %s", e.Index, []float64(e.Coord), e.Reason)
+}
+
+func (e *CoordinateError) Unwrap() error {
+	return ErrInvalidCoordinate
+}
+
+// checkCoords rejects non-finite coordinates always and out of range
+// longitudes/latitudes for geographic SRIDs, SRID 0 means SRID
+func checkCoords(g geom.T) error {
+	if isNil(g) {
+		return nil
+	}
+
+	srid := g.SRID()
+	if srid == 0 {
+		srid = SRID
+	}
+
+	_, err := checkCoordsFrom(g, GeographicSRIDs[srid], 0)
+
+	return err
+}
+
+func checkCoordsFrom(g geom.T, geographic bool, index int) (int, error) {
+	if collection, ok := g.(*geom.GeometryCollection); ok {
+		var err error
+
+		for _, child := range collection.Geoms() {
+			if index, err = checkCoordsFrom(child, geographic, index); err != nil {
+				return index, err
+			}
+		}
+
+		return index, nil
+	}
+
+	flatCoords, stride := g.FlatCoords(), g.Stride()
+
+	for i := 0; i < len(flatCoords); i += stride {
+		c := geom.Coord(flatCoords[i : i+stride])
+
+		var reason string
+
+		switch {
+		case !isFinite(c):
+			reason = CoordinateNotFinite
+		case geographic && (c[0] < -180 || c[0] > 180):
+			reason = LongitudeOutOfRange
+		case geographic && (c[1] < -90 || c[1] > 90):
+			reason = LatitudeOutOfRange
+		default:
+			index++
+			continue
+		}
+
+		return index, &CoordinateError{Index: index, Coord: c.Clone(), Reason: reason}
+	}
+
+	return index, nil
+}
+
+func isFinite(c geom.Coord) bool {
+	for _, value := range c {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return false
+		}
+	}
+
+	return true
+}
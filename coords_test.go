@@ -0,0 +1,99 @@
+package georm
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestCheckCoords(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Geom   geom.T
+		Expect *CoordinateError
+	}{
+		{
+			Name: "valid",
+			Geom: geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{-180, -90}, {180, 90}}),
+		},
+		{
+			Name:   "nan",
+			Geom:   geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {math.NaN(), 0}}),
+			Expect: &CoordinateError{Index: 1, Reason: CoordinateNotFinite},
+		},
+		{
+			Name:   "inf z",
+			Geom:   geom.NewPoint(geom.XYZ).MustSetCoords(geom.Coord{0, 0, math.Inf(-1)}),
+			Expect: &CoordinateError{Index: 0, Reason: CoordinateNotFinite},
+		},
+		{
+			Name:   "longitude out of range",
+			Geom:   geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{500, 0}).SetSRID(4326),
+			Expect: &CoordinateError{Index: 0, Reason: LongitudeOutOfRange},
+		},
+		{
+			Name:   "latitude out of range",
+			Geom:   geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 91}, {0, 0}}}),
+			Expect: &CoordinateError{Index: 2, Reason: LatitudeOutOfRange},
+		},
+		{
+			Name: "projected srid",
+			Geom: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{4187591, 7509137}).SetSRID(3857),
+		},
+		{
+			Name:   "projected srid nan",
+			Geom:   geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{math.NaN(), 0}).SetSRID(3857),
+			Expect: &CoordinateError{Index: 0, Reason: CoordinateNotFinite},
+		},
+		{
+			Name: "geometry collection",
+			Geom: geom.NewGeometryCollection().MustPush(
+				geom.NewMultiPoint(geom.XY).MustSetCoords([]geom.Coord{{1, 1}, {2, 2}}),
+				geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{200, 0}),
+			),
+			Expect: &CoordinateError{Index: 2, Reason: LongitudeOutOfRange},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := checkCoords(test.Geom)
+			if test.Expect == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidCoordinate)
+
+			var coordErr *CoordinateError
+			require.ErrorAs(t, err, &coordErr)
+			assert.Equal(t, test.Expect.Index, coordErr.Index)
+			assert.Equal(t, test.Expect.Reason, coordErr.Reason)
+		})
+	}
+}
+
+func TestCoordinateErrorError(t *testing.T) {
+	err := &CoordinateError{Index: 3, Coord: geom.Coord{500, 0}, Reason: LongitudeOutOfRange}
+	assert.Equal(t, "invalid coordinate #3 [500 0]: longitude out of range", err.Error())
+}
+
+func TestGeometryValueExpectInvalidCoordinate(t *testing.T) {
+	_, err := New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{500, 0})).Value()
+	require.ErrorIs(t, err, ErrInvalidCoordinate)
+
+	_, err = New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{math.NaN(), 0})).Value()
+	require.ErrorIs(t, err, ErrInvalidCoordinate)
+}
+
+func TestGeometryUnmarshalJSONExpectInvalidCoordinate(t *testing.T) {
+	var g Point
+
+	err := json.Unmarshal([]byte(`{"type":"Point","coordinates":[500,0]}`), &g)
+	require.ErrorIs(t, err, ErrInvalidCoordinate)
+	assert.Nil(t, g.Geom)
+}
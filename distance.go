@@ -0,0 +1,246 @@
+package georm
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/twpayne/go-geom"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Distance is a length in meters
+type Distance float64
+
+// Units of Distance
+const (
+	Millimeter   Distance = 0.001
+	Centimeter   Distance = 0.01
+	Meter        Distance = 1
+	Kilometer    Distance = 1000
+	Foot         Distance = 0.3048
+	Yard         Distance = 0.9144
+	Mile         Distance = 1609.344
+	NauticalMile Distance = 1852
+)
+
+// Meters returns the distance in meters
+func (d Distance) Meters() float64 { return float64(d) }
+
+// WebMercatorSRID is the SRID of Web Mercator projection, its units are meters
+// only on the equator and distances are scaled by latitude in WithinDistance
+const WebMercatorSRID = 3857
+
+// minMeridianRadius is the radius of curvature of the WGS 84 meridian on the equator,
+// the smallest one, so a degree of latitude is not shorter than it gives
+const minMeridianRadius = wgs84A * (1 - wgs84F) * (1 - wgs84F)
+
+// distanceMargin enlarges bounding boxes of distances for rounding errors
+const distanceMargin = 1.01
+
+// WithinDistance returns condition matching rows which geometry is within
+// the distance of geometry, e.g. addresses within 500 meters of a point:
+//
+//	db.Where(georm.WithinDistance("geo_point", point, 500*georm.Meter)).Find(&addresses)
+//
+// The strategy depends on the type of the column declared in the model
+// (GormDataType of the field or `gorm:"type:..."` tag), the column of
+// unknown type is considered a geometry of the SRID of geometry, geometry
+// of SRID 0 is considered of SRID:
+//   - geography columns are compared with ST_DWithin of geography
+//   - geometry columns of GeographicSRIDs are compared with ST_DWithin of geography
+//     on the spheroid, bounding box of the distance around geometry transformed
+//     to the SRID of the column is matched with the && operator first,
+//     so the spatial index of the column is applied
+//   - geometry columns of WebMercatorSRID are compared with ST_DWithin,
+//     the distance is scaled by latitude of geometry
+//   - geometry columns of other SRIDs, which units are not known, are
+//     compared as geometry columns of GeographicSRIDs after transformation
+//     to SRID, the bounding box is transformed to the SRID of the column
+func WithinDistance(field string, geometry Geometric, distance Distance) clause.Expression {
+	return withinDistance{field: field, geometry: geometry.GeomT(), distance: distance}
+}
+
+type withinDistance struct {
+	field    string
+	geometry geom.T
+	distance Distance
+}
+
+// Build impl clause.Expression
+func (w withinDistance) Build(builder clause.Builder) {
+	w.expression(builder).Build(builder)
+}
+
+func (w withinDistance) expression(builder clause.Builder) clause.Expression {
+	if isNil(w.geometry) {
+		return clause.Expr{SQL: "FALSE"}
+	}
+
+	// SRID 0 means SRID, the copy keeps geometry of the caller
+	if w.geometry.SRID() == 0 {
+		w.geometry = setSRID(cloneGeometry(w.geometry), SRID)
+	}
+
+	var (
+		column = clause.Column{Name: w.field}
+		srid   = w.geometry.SRID()
+		meters = w.distance.Meters()
+	)
+
+	geography, columnSRID, ok := columnType(builder, w.field)
+	if !ok {
+		columnSRID = srid
+	}
+
+	switch {
+	case geography:
+		return clause.Expr{SQL: "ST_DWithin(?, ?::geography, ?)", Vars: []any{column, w.value(columnSRID), meters}}
+	case GeographicSRIDs[columnSRID]:
+		return clause.Expr{
+			SQL: "(? && ? AND ST_DWithin(?::geography, ?::geography, ?, true))",
+			Vars: []any{
+				column, w.bounds(columnSRID),
+				column, w.value(columnSRID), meters,
+			},
+		}
+	case columnSRID == WebMercatorSRID:
+		meters *= webMercatorScale(w.geometry)
+
+		return clause.Expr{SQL: "ST_DWithin(?, ?, ?)", Vars: []any{column, w.value(columnSRID), meters}}
+	default:
+		// the box is segmentized to follow curved parallels and meridians in the projection
+		return clause.Expr{
+			SQL: "(? && ST_Transform(ST_Segmentize(?, ?), ?) AND ST_DWithin(ST_Transform(?, ?)::geography, ?::geography, ?, true))",
+			Vars: []any{
+				column, w.bounds(4326), degrees(meters / minMeridianRadius), columnSRID,
+				column, 4326, w.value(4326), meters,
+			},
+		}
+	}
+}
+
+// value returns geometry transformed to srid
+func (w withinDistance) value(srid int) clause.Expr {
+	if w.geometry.SRID() == srid {
+		return clause.Expr{SQL: "?::geometry", Vars: []any{New(w.geometry)}}
+	}
+
+	return clause.Expr{SQL: "ST_Transform(?::geometry, ?)", Vars: []any{New(w.geometry), srid}}
+}
+
+// bounds returns the box of distanceBounds in the geographic srid, it is
+// calculated by the database when geometry is of other SRID
+func (w withinDistance) bounds(srid int) clause.Expr {
+	var (
+		meters = w.distance.Meters()
+		angle  = meters / minMeridianRadius
+	)
+
+	if w.geometry.SRID() == srid {
+		box := distanceBounds(w.geometry.Bounds(), meters)
+
+		return clause.Expr{SQL: "ST_MakeEnvelope(?, ?, ?, ?, ?)", Vars: []any{box.MinX, box.MinY, box.MaxX, box.MaxY, srid}}
+	}
+
+	// the same steps as in distanceBounds
+	box := clause.Expr{SQL: "SELECT ST_Transform(?::geometry, ?)::box2d AS box", Vars: []any{New(w.geometry), srid}}
+
+	lat := clause.Expr{
+		SQL: "SELECT ST_XMin(box) AS min_x, ST_XMax(box) AS max_x," +
+			" GREATEST(ST_YMin(box) - ?, -90) AS min_y, LEAST(ST_YMax(box) + ?, 90) AS max_y FROM (?) AS box",
+		Vars: []any{degrees(angle) * distanceMargin, degrees(angle) * distanceMargin, box},
+	}
+
+	lon := clause.Expr{
+		SQL: "SELECT *, CASE WHEN sin < 1 THEN degrees(asin(sin)) * ? END AS dx FROM" +
+			" (SELECT *, ? / cos(radians(GREATEST(abs(min_y), abs(max_y)))) AS sin FROM (?) AS lat) AS lon",
+		Vars: []any{distanceMargin, math.Sin(min(angle, math.Pi/2)), lat},
+	}
+
+	return clause.Expr{
+		SQL: "(SELECT CASE WHEN min_x - dx >= -180 AND max_x + dx <= 180" +
+			" THEN ST_MakeEnvelope(min_x - dx, min_y, max_x + dx, max_y, ?)" +
+			" ELSE ST_MakeEnvelope(-180, min_y, 180, max_y, ?) END FROM (?) AS bounds)",
+		Vars: []any{srid, srid, lon},
+	}
+}
+
+var columnTypeRegexp = regexp.MustCompile(`(?i)^\s*(geometry|geography)\s*(?:\(\s*\w+\s*(?:,\s*(\d+)\s*)?\))?`)
+
+// columnType returns declared type of the column of the statement model:
+// whether it is geography and its SRID
+func columnType(builder clause.Builder, name string) (geography bool, srid int, ok bool) {
+	stmt, isStmt := builder.(*gorm.Statement)
+	if !isStmt || stmt.Schema == nil {
+		return false, 0, false
+	}
+
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+
+	field := stmt.Schema.LookUpField(name)
+	if field == nil {
+		return false, 0, false
+	}
+
+	matches := columnTypeRegexp.FindStringSubmatch(string(field.DataType))
+	if matches == nil {
+		return false, 0, false
+	}
+
+	geography = strings.EqualFold(matches[1], "geography")
+
+	if matches[2] == "" {
+		// geography is lon/lat of WGS 84 by default
+		return geography, 4326, geography
+	}
+
+	srid, err := strconv.Atoi(matches[2])
+
+	return geography, srid, err == nil
+}
+
+// distanceBounds returns lon/lat box covering all points within meters of the bounds
+// on WGS 84 ellipsoid, the box covers all longitudes when it would cross the antimeridian
+func distanceBounds(bounds *geom.Bounds, meters float64) Box2D {
+	var (
+		angle = meters / minMeridianRadius
+		dy    = degrees(angle) * distanceMargin
+		box   = Box2D{MinX: -180, MinY: bounds.Min(1) - dy, MaxX: 180, MaxY: bounds.Max(1) + dy}
+	)
+
+	box.MinY, box.MaxY = max(box.MinY, -90), min(box.MaxY, 90)
+
+	// the longitude extent of a circle grows with latitude
+	maxLat := radians(max(math.Abs(box.MinY), math.Abs(box.MaxY)))
+
+	if sin := math.Sin(min(angle, math.Pi/2)) / math.Cos(maxLat); sin < 1 {
+		dx := degrees(math.Asin(sin)) * distanceMargin
+
+		if bounds.Min(0)-dx >= -180 && bounds.Max(0)+dx <= 180 {
+			box.MinX, box.MaxX = bounds.Min(0)-dx, bounds.Max(0)+dx
+		}
+	}
+
+	return box
+}
+
+// webMercatorScale returns scale of Web Mercator at the center of lon/lat
+// or Web Mercator geometry, 1 for other SRIDs
+func webMercatorScale(g geom.T) float64 {
+	bounds := g.Bounds()
+	y := (bounds.Min(1) + bounds.Max(1)) / 2
+
+	switch {
+	case GeographicSRIDs[g.SRID()]:
+		return 1 / math.Cos(radians(y))
+	case g.SRID() == WebMercatorSRID:
+		return 1 / math.Cos(math.Atan(math.Sinh(y/wgs84A)))
+	default:
+		return 1
+	}
+}
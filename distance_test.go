@@ -0,0 +1,192 @@
+package georm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+	"gorm.io/gorm"
+)
+
+type testPlace struct {
+	ID       uint `gorm:"primaryKey"`
+	Point    Point
+	Location Point `gorm:"type:geography(Point,4326)"`
+	Mercator Point `gorm:"type:geometry(Point,3857)"`
+	UTM      Point `gorm:"type:geometry(Point, 32637)"`
+	Any      Geometry[geom.T]
+}
+
+func TestWithinDistance(t *testing.T) {
+	db := dryRunDB(t)
+
+	point := NewPoint(37.6176, 55.7558)
+
+	value, err := point.Value()
+	require.NoError(t, err)
+
+	tests := []struct {
+		Name     string
+		Field    string
+		Geometry Geometric
+		Expect   string
+	}{
+		{
+			Name:     "geography",
+			Field:    "location",
+			Geometry: point,
+			Expect:   `WHERE ST_DWithin("location", '{value}'::geometry::geography, 500)`,
+		},
+		{
+			Name:     "geographic geometry",
+			Field:    "test_places.point",
+			Geometry: point,
+			Expect:   `WHERE ("test_places"."point" && ST_MakeEnvelope(37.6094`,
+		},
+		{
+			Name:     "geographic geometry distance",
+			Field:    "point",
+			Geometry: point,
+			Expect:   `AND ST_DWithin("point"::geography, '{value}'::geometry::geography, 500, true))`,
+		},
+		{
+			Name:     "web mercator",
+			Field:    "mercator",
+			Geometry: point,
+			Expect:   `WHERE ST_DWithin("mercator", ST_Transform('{value}'::geometry, 3857), 888.539`,
+		},
+		{
+			Name:     "projected",
+			Field:    "utm",
+			Geometry: point,
+			Expect:   `WHERE ("utm" && ST_Transform(ST_Segmentize(ST_MakeEnvelope(37.6094`,
+		},
+		{
+			Name:     "projected distance",
+			Field:    "utm",
+			Geometry: point,
+			Expect:   `AND ST_DWithin(ST_Transform("utm", 4326)::geography, '{value}'::geometry::geography, 500, true))`,
+		},
+		{
+			Name:     "unknown srid",
+			Field:    "any",
+			Geometry: point,
+			Expect:   `WHERE ("any" && ST_MakeEnvelope(37.6094`,
+		},
+		{
+			Name:     "unknown column",
+			Field:    "other",
+			Geometry: New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{500000, 6000000}).SetSRID(32637)),
+			Expect:   `AND ST_DWithin(ST_Transform("other", 4326)::geography, ST_Transform('{value}'::geometry, 4326)::geography, 500, true))`,
+		},
+		{
+			Name:     "projected geometry in geographic column",
+			Field:    "point",
+			Geometry: New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{500000, 6000000}).SetSRID(32637)),
+			Expect:   `AND ST_DWithin("point"::geography, ST_Transform('{value}'::geometry, 4326)::geography, 500, true))`,
+		},
+		{
+			Name:     "projected geometry bounds",
+			Field:    "point",
+			Geometry: New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{500000, 6000000}).SetSRID(32637)),
+			Expect: `WHERE ("point" && (SELECT CASE WHEN min_x - dx >= -180 AND max_x + dx <= 180` +
+				` THEN ST_MakeEnvelope(min_x - dx, min_y, max_x + dx, max_y, 4326) ELSE ST_MakeEnvelope(-180, min_y, 180, max_y, 4326) END` +
+				` FROM (SELECT *, CASE WHEN sin < 1 THEN degrees(asin(sin)) * 1.01 END AS dx` +
+				` FROM (SELECT *, 0.00007892112506341503 / cos(radians(GREATEST(abs(min_y), abs(max_y)))) AS sin` +
+				` FROM (SELECT ST_XMin(box) AS min_x, ST_XMax(box) AS max_x,` +
+				` GREATEST(ST_YMin(box) - 0.00456706585910443, -90) AS min_y, LEAST(ST_YMax(box) + 0.00456706585910443, 90) AS max_y` +
+				` FROM (SELECT ST_Transform('{value}'::geometry, 4326)::box2d AS box) AS box) AS lat) AS lon) AS bounds) AND`,
+		},
+		{
+			Name:     "srid 0 geometry",
+			Field:    "utm",
+			Geometry: New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{37.6176, 55.7558})),
+			Expect:   `WHERE ("utm" && ST_Transform(ST_Segmentize(ST_MakeEnvelope(37.6094`,
+		},
+		{
+			Name:     "srid 0 geometry distance",
+			Field:    "utm",
+			Geometry: New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{37.6176, 55.7558})),
+			Expect:   `AND ST_DWithin(ST_Transform("utm", 4326)::geography, '{value}'::geometry::geography, 500, true))`,
+		},
+		{
+			Name:     "srid 0 geometry in web mercator column",
+			Field:    "mercator",
+			Geometry: New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{37.6176, 55.7558})),
+			Expect:   `WHERE ST_DWithin("mercator", ST_Transform('{value}'::geometry, 3857), 888.539`,
+		},
+		{
+			Name:     "nil geometry",
+			Field:    "point",
+			Geometry: Point{},
+			Expect:   `WHERE FALSE`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if g := test.Geometry.GeomT(); g != nil {
+				// geometry of SRID 0 is written as of SRID
+				if g.SRID() == 0 {
+					g = setSRID(cloneGeometry(g), SRID)
+				}
+
+				value, err = New(g).Value()
+				require.NoError(t, err)
+			}
+
+			sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(WithinDistance(test.Field, test.Geometry, 500*Meter)).Find(&[]testPlace{})
+			})
+
+			assert.Contains(t, sql, strings.ReplaceAll(test.Expect, "{value}", value.(string)))
+		})
+	}
+}
+
+func TestDistanceBounds(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Center geom.Coord
+		Radius Distance
+		Full   bool
+	}{
+		{Name: "equator", Center: geom.Coord{0, 0}, Radius: 500 * Kilometer},
+		{Name: "moscow", Center: geom.Coord{37.6176, 55.7558}, Radius: 20 * Kilometer},
+		{Name: "north", Center: geom.Coord{-40, 80}, Radius: 300 * Kilometer},
+		{Name: "south", Center: geom.Coord{120, -70}, Radius: 2 * NauticalMile},
+		{Name: "antimeridian", Center: geom.Coord{179.9, 10}, Radius: 50 * Mile, Full: true},
+		{Name: "pole", Center: geom.Coord{10, 89.5}, Radius: 100 * Kilometer, Full: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			center := New(geom.NewPoint(geom.XY).MustSetCoords(test.Center).SetSRID(SRID))
+
+			box := distanceBounds(center.Geom.Bounds(), test.Radius.Meters())
+			assert.Equal(t, test.Full, box.MinX == -180 && box.MaxX == 180)
+
+			circle, err := Circle(center, test.Radius.Meters(), 64)
+			require.NoError(t, err)
+
+			for _, c := range circle.Geom.Coords()[0] {
+				lon := c[0]
+				if lon > 180 {
+					lon -= 360
+				} else if lon < -180 {
+					lon += 360
+				}
+
+				assert.True(t, lon >= box.MinX && lon <= box.MaxX && c[1] >= box.MinY && c[1] <= box.MaxY, "%v out of %+v", c, box)
+			}
+		})
+	}
+}
+
+func TestDistanceUnits(t *testing.T) {
+	assert.InDelta(t, 1609.344, (1 * Mile).Meters(), 1e-9)
+	assert.InDelta(t, 2500, (2.5 * Kilometer).Meters(), 1e-9)
+	assert.InDelta(t, 0.3048, Foot.Meters(), 1e-9)
+}
@@ -0,0 +1,140 @@
+package georm
+
+import (
+	"math"
+	"reflect"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+)
+
+// EqualExact reports whether geometries have the same type, layout, SRID
+// and structure and all coordinates are equal in the same order,
+// as PostGIS ST_OrderingEquals with SRID check. Nil geometries are equal
+func (g Geometry[T]) EqualExact(other Geometric) bool {
+	a, b := g.GeomT(), other.GeomT()
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.SRID() == b.SRID() && equalGeometries(a, b, 0, false)
+}
+
+// Equal reports whether geometries have the same type, layout and structure
+// and coordinates differ by no more than tolerance, rings may start from
+// different vertices. SRID 0 matches any SRID, nil geometries are equal
+func (g Geometry[T]) Equal(other Geometric, tolerance float64) bool {
+	a, b := g.GeomT(), other.GeomT()
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return equalSRID(a, b) && equalGeometries(a, b, tolerance, true)
+}
+
+// EqualTopo reports whether geometries cover the same set of points
+// as PostGIS ST_Equals, regardless of types, vertex order and repeated
+// vertices. SRID 0 matches any SRID, empty and nil geometries are equal
+func (g Geometry[T]) EqualTopo(other Geometric) bool {
+	a, b := g.GeomT(), other.GeomT()
+	if a != nil && b != nil && !equalSRID(a, b) {
+		return false
+	}
+
+	ra, rb := newRelateGeometry(a), newRelateGeometry(b)
+	if ra.empty() || rb.empty() {
+		return ra.empty() && rb.empty()
+	}
+
+	return covers(ra, rb) && covers(rb, ra)
+}
+
+func equalSRID(a, b geom.T) bool {
+	return a.SRID() == b.SRID() || a.SRID() == 0 || b.SRID() == 0
+}
+
+// equalGeometries compares structure and coordinates of geometries,
+// rotate allows rings to start from different vertices
+func equalGeometries(a, b geom.T, tolerance float64, rotate bool) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || a.Layout() != b.Layout() {
+		return false
+	}
+
+	if gc, ok := a.(*geom.GeometryCollection); ok {
+		other := b.(*geom.GeometryCollection)
+		if gc.NumGeoms() != other.NumGeoms() {
+			return false
+		}
+
+		for i := 0; i < gc.NumGeoms(); i++ {
+			if !equalGeometries(gc.Geom(i), other.Geom(i), tolerance, rotate) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if !slices.Equal(a.Ends(), b.Ends()) || !slices.EqualFunc(a.Endss(), b.Endss(), slices.Equal[[]int]) {
+		return false
+	}
+
+	var (
+		stride     = a.Stride()
+		flatCoordA = a.FlatCoords()
+		flatCoordB = b.FlatCoords()
+		ringEnds   []int
+	)
+
+	switch a.(type) {
+	case *geom.Polygon:
+		ringEnds = a.Ends()
+	case *geom.MultiPolygon:
+		ringEnds = slices.Concat(a.Endss()...)
+	}
+
+	if !rotate || len(ringEnds) == 0 {
+		return equalCoords(flatCoordA, flatCoordB, tolerance)
+	}
+
+	offset := 0
+	for _, end := range ringEnds {
+		if !equalRings(flatCoordA[offset:end], flatCoordB[offset:end], stride, tolerance) {
+			return false
+		}
+
+		offset = end
+	}
+
+	return true
+}
+
+func equalCoords(a, b []float64, tolerance float64) bool {
+	return slices.EqualFunc(a, b, func(x, y float64) bool {
+		return x == y || math.Abs(x-y) <= tolerance
+	})
+}
+
+// equalRings compares closed rings of the same length starting from any vertex
+func equalRings(a, b []float64, stride int, tolerance float64) bool {
+	n := len(a)/stride - 1
+	if n < 1 || !equalCoords(a[:stride], a[n*stride:], 0) || !equalCoords(b[:stride], b[n*stride:], 0) {
+		// not closed rings are compared as lines
+		return equalCoords(a, b, tolerance)
+	}
+
+	for shift := 0; shift < n; shift++ {
+		equal := true
+
+		for i := 0; i < n && equal; i++ {
+			j := (i + shift) % n
+			equal = equalCoords(a[i*stride:(i+1)*stride], b[j*stride:(j+1)*stride], tolerance)
+		}
+
+		if equal {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+package georm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twpayne/go-geom"
+)
+
+func TestGeometryEqual(t *testing.T) {
+	tenth, fifth := 0.1, 0.2
+	square := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}).SetSRID(SRID)
+
+	tests := []struct {
+		Name  string
+		Geom  geom.T
+		Other geom.T
+		Exact bool
+		Equal bool
+		Topo  bool
+	}{
+		{
+			Name:  "nil",
+			Geom:  nil,
+			Other: nil,
+			Exact: true,
+			Equal: true,
+			Topo:  true,
+		},
+		{
+			Name:  "nil and point",
+			Geom:  nil,
+			Other: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 1}),
+		},
+		{
+			Name:  "same polygon",
+			Geom:  square,
+			Other: square,
+			Exact: true,
+			Equal: true,
+			Topo:  true,
+		},
+		{
+			Name:  "float noise",
+			Geom:  geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{tenth + fifth, 1}).SetSRID(SRID),
+			Other: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{0.3, 1}).SetSRID(SRID),
+			Equal: true,
+		},
+		{
+			Name:  "far points",
+			Geom:  geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{0, 1}).SetSRID(SRID),
+			Other: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{0.1, 1}).SetSRID(SRID),
+		},
+		{
+			Name:  "unknown SRID",
+			Geom:  square,
+			Other: geom.NewPolygon(geom.XY).MustSetCoords(square.Coords()),
+			Equal: true,
+			Topo:  true,
+		},
+		{
+			Name:  "different SRID",
+			Geom:  square,
+			Other: geom.NewPolygon(geom.XY).MustSetCoords(square.Coords()).SetSRID(3857),
+		},
+		{
+			Name:  "ring rotation",
+			Geom:  square,
+			Other: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{1, 1}, {0, 1}, {0, 0}, {1, 0}, {1, 1}}}).SetSRID(SRID),
+			Equal: true,
+			Topo:  true,
+		},
+		{
+			Name:  "ring orientation",
+			Geom:  square,
+			Other: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}}).SetSRID(SRID),
+			Topo:  true,
+		},
+		{
+			Name:  "extra vertex",
+			Geom:  square,
+			Other: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {0.5, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}).SetSRID(SRID),
+			Topo:  true,
+		},
+		{
+			Name:  "polygon and multipolygon",
+			Geom:  square,
+			Other: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{square.Coords()}).SetSRID(SRID),
+			Topo:  true,
+		},
+		{
+			Name:  "different layout",
+			Geom:  geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}}),
+			Other: geom.NewLineString(geom.XYZ).MustSetCoords([]geom.Coord{{0, 0, 0}, {1, 1, 0}}),
+			Topo:  true,
+		},
+		{
+			Name:  "reversed line",
+			Geom:  geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}}),
+			Other: geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{1, 1}, {0, 0}}),
+			Topo:  true,
+		},
+		{
+			Name:  "different shape",
+			Geom:  square,
+			Other: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}}).SetSRID(SRID),
+		},
+		{
+			Name: "collection",
+			Geom: geom.NewGeometryCollection().MustPush(
+				geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{42, 42}),
+				square,
+			),
+			Other: geom.NewGeometryCollection().MustPush(
+				geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{42, 42 + 1e-12}),
+				square,
+			),
+			Equal: true,
+		},
+		{
+			Name:  "empty geometries",
+			Geom:  geom.NewPolygon(geom.XY),
+			Other: geom.NewLineString(geom.XY),
+			Topo:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			g, other := New(test.Geom), New(test.Other)
+
+			assert.Equal(t, test.Exact, g.EqualExact(other), "EqualExact")
+			assert.Equal(t, test.Equal, g.Equal(other, 1e-9), "Equal")
+			assert.Equal(t, test.Topo, g.EqualTopo(other), "EqualTopo")
+
+			assert.Equal(t, test.Exact, other.EqualExact(g), "symmetric EqualExact")
+			assert.Equal(t, test.Equal, other.Equal(g, 1e-9), "symmetric Equal")
+			assert.Equal(t, test.Topo, other.EqualTopo(g), "symmetric EqualTopo")
+		})
+	}
+}
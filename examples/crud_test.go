@@ -3,24 +3,24 @@ package examples
 import (
 	"testing"
 
-	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/twpayne/go-geom"
 	"gorm.io/gorm"
 
 	"github.com/ybru-tech/georm"
+	"github.com/ybru-tech/georm/geomtest"
 )
 
 func equalTableWithGeometries(t *testing.T, expect, actual TableWithAllGeometries) {
 	t.Helper()
 
-	assert.Equal(t, expect.Point, actual.Point)
-	assert.Equal(t, expect.LineString, actual.LineString)
-	assert.Equal(t, expect.Polygon, actual.Polygon)
-	assert.Equal(t, expect.MultiPoint, actual.MultiPoint)
-	assert.Equal(t, expect.MultiLineString, actual.MultiLineString)
-	assert.Equal(t, expect.MultiPolygon, actual.MultiPolygon)
-	assert.Equal(t, expect.GeometryCollection, actual.GeometryCollection)
+	geomtest.Equal(t, expect.Point, actual.Point, 1e-9)
+	geomtest.Equal(t, expect.LineString, actual.LineString, 1e-9)
+	geomtest.Equal(t, expect.Polygon, actual.Polygon, 1e-9)
+	geomtest.Equal(t, expect.MultiPoint, actual.MultiPoint, 1e-9)
+	geomtest.Equal(t, expect.MultiLineString, actual.MultiLineString, 1e-9)
+	geomtest.Equal(t, expect.MultiPolygon, actual.MultiPolygon, 1e-9)
+	geomtest.Equal(t, expect.GeometryCollection, actual.GeometryCollection, 1e-9)
 }
 
 type TableWithAllGeometries struct {
@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/ybru-tech/georm"
 	"github.com/ybru-tech/georm/examples/testutil"
 )
 
@@ -13,6 +14,10 @@ var storage *Storage
 func TestMain(m *testing.M) {
 	conn, closer := testutil.InitTempDB()
 
+	if err := conn.Use(georm.Plugin{}); err != nil {
+		panic(err)
+	}
+
 	storage = NewStorage(conn)
 
 	if err := storage.MigrationTables(); err != nil {
@@ -12,6 +12,9 @@ type Zone struct {
 	ID         uint `gorm:"primaryKey"`
 	Title      string
 	GeoPolygon georm.Polygon
+
+	// Addresses inside the zone, loaded by Preload("Addresses")
+	Addresses []Address `gorm:"-" georm:"relation=contains;foreignGeom=geo_point"`
 }
 
 type Route struct {
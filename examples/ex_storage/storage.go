@@ -58,6 +58,49 @@ func (s *Storage) FindAddressesInPolygon(polygon georm.Polygon) ([]Address, erro
 
 	return addresses, nil
 }
+
+// FindAddressesInBBox finds addresses inside a bounding box, e.g. map viewport
+func (s *Storage) FindAddressesInBBox(minX, minY, maxX, maxY float64) ([]Address, error) {
+	var addresses []Address
+
+	tx := s.db.
+		Model(&Address{}).
+		Where(georm.InBBox("geo_point", minX, minY, maxX, maxY, georm.SRID))
+
+	if err := tx.Find(&addresses).Error; err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+// FindAddressesNear finds addresses within the distance of a point
+func (s *Storage) FindAddressesNear(point georm.Point, distance georm.Distance) ([]Address, error) {
+	var addresses []Address
+
+	tx := s.db.
+		Model(&Address{}).
+		Where(georm.WithinDistance("geo_point", point, distance))
+
+	if err := tx.Find(&addresses).Error; err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+// AddressesExtent returns bounding box of all addresses
+func (s *Storage) AddressesExtent() (georm.Box2D, error) {
+	var extent georm.Box2D
+
+	err := s.db.
+		Model(&Address{}).
+		Select("ST_Extent(geo_point)").
+		Row().
+		Scan(&extent)
+
+	return extent, err
+}
 func (s *Storage) UpdateAddress(address *Address) error {
 	return s.db.Updates(address).Error
 }
@@ -94,6 +137,22 @@ func (s *Storage) FindZonesContainingPoint(point georm.Point) ([]Zone, error) {
 
 	return zones, nil
 }
+
+// FindZonesWithAddresses finds zones with addresses inside each of them,
+// addresses of all zones are loaded by one spatial join
+func (s *Storage) FindZonesWithAddresses(ids ...uint) ([]Zone, error) {
+	var zones []Zone
+
+	tx := s.db.
+		Preload("Addresses", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Order("id")
+
+	if err := tx.Find(&zones, ids).Error; err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
 func (s *Storage) UpdateZone(zone *Zone) error {
 	return s.db.Updates(zone).Error
 }
@@ -1,6 +1,7 @@
 package ex_storage
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -13,7 +14,7 @@ import (
 func TestStorageAddressCRUD(t *testing.T) {
 	address := &Address{
 		Address:  "some address",
-		GeoPoint: georm.New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{42, 42}).SetSRID(4326)),
+		GeoPoint: georm.NewPoint(42, 42),
 	}
 
 	err := storage.AddAddresses(address)
@@ -27,7 +28,7 @@ func TestStorageAddressCRUD(t *testing.T) {
 	dataForUpdate := &Address{
 		ID:       address.ID,
 		Address:  "some address updated",
-		GeoPoint: georm.New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{32, 32}).SetSRID(4326)),
+		GeoPoint: georm.NewPoint(32, 32),
 	}
 
 	err = storage.UpdateAddress(dataForUpdate)
@@ -47,21 +48,15 @@ func TestStorageAddressCRUD(t *testing.T) {
 
 func TestStorage_FindAddressesInPolygon(t *testing.T) {
 	addresses := []*Address{
-		{Address: "address 1", GeoPoint: georm.New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{12, 14}).SetSRID(4326))},
-		{Address: "address 2", GeoPoint: georm.New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{14, 14}).SetSRID(4326))},
-		{Address: "address 3", GeoPoint: georm.New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{14, 12}).SetSRID(4326))},
-		{Address: "address 4", GeoPoint: georm.New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{12, 12}).SetSRID(4326))},
-		{Address: "address 5", GeoPoint: georm.New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{20, 20}).SetSRID(4326))},
-		{Address: "address 6", GeoPoint: georm.New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{10, 10}).SetSRID(4326))},
+		{Address: "address 1", GeoPoint: georm.NewPoint(12, 14)},
+		{Address: "address 2", GeoPoint: georm.NewPoint(14, 14)},
+		{Address: "address 3", GeoPoint: georm.NewPoint(14, 12)},
+		{Address: "address 4", GeoPoint: georm.NewPoint(12, 12)},
+		{Address: "address 5", GeoPoint: georm.NewPoint(20, 20)},
+		{Address: "address 6", GeoPoint: georm.NewPoint(10, 10)},
 	}
 
-	polygon := georm.Polygon{
-		Geom: geom.NewPolygon(geom.XY).MustSetCoords(
-			[][]geom.Coord{
-				{{11, 11}, {11, 15}, {15, 15}, {15, 11}, {11, 11}},
-			},
-		).SetSRID(4326),
-	}
+	polygon := georm.NewPolygon([]geom.Coord{{11, 11}, {11, 15}, {15, 15}, {15, 11}, {11, 11}})
 
 	err := storage.AddAddresses(addresses...)
 	require.NoError(t, err)
@@ -86,4 +81,136 @@ func TestStorage_FindAddressesInPolygon(t *testing.T) {
 		require.NotNilf(t, actualAddress, "expected address %#v not found in actual", expectAddress)
 		require.Equal(t, expectAddress, actualAddress)
 	}
+
+	// in-memory predicate agrees with ST_Contains
+	for _, address := range addresses {
+		inPolygon := slices.ContainsFunc(addressesInPolygon, func(a Address) bool { return a.ID == address.ID })
+		require.Equal(t, inPolygon, polygon.Contains(address.GeoPoint), address.Address)
+	}
+}
+
+func TestStorage_FindAddressesInBBox(t *testing.T) {
+	addresses := []*Address{
+		{Address: "address in bbox 1", GeoPoint: georm.NewPoint(101, 51)},
+		{Address: "address in bbox 2", GeoPoint: georm.NewPoint(104, 54)},
+		{Address: "address out of bbox", GeoPoint: georm.NewPoint(106, 51)},
+	}
+
+	err := storage.AddAddresses(addresses...)
+	require.NoError(t, err)
+
+	addressesInBBox, err := storage.FindAddressesInBBox(100, 50, 105, 55)
+	require.NoError(t, err)
+
+	require.Len(t, addressesInBBox, 2)
+	require.Equal(t, *addresses[0], addressesInBBox[0])
+	require.Equal(t, *addresses[1], addressesInBBox[1])
+
+	extent, err := storage.AddressesExtent()
+	require.NoError(t, err)
+
+	require.LessOrEqual(t, extent.MinX, 101.0)
+	require.LessOrEqual(t, extent.MinY, 51.0)
+	require.GreaterOrEqual(t, extent.MaxX, 106.0)
+	require.GreaterOrEqual(t, extent.MaxY, 54.0)
+}
+
+func TestStorage_FindZonesWithAddresses(t *testing.T) {
+	addresses := []*Address{
+		{Address: "zone address 1", GeoPoint: georm.NewPoint(-51, -51)},
+		{Address: "zone address 2", GeoPoint: georm.NewPoint(-54, -54)},
+		{Address: "address out of zones", GeoPoint: georm.NewPoint(-70, -70)},
+	}
+
+	zones := []*Zone{
+		{Title: "zone 1", GeoPolygon: georm.NewPolygon([]geom.Coord{{-60, -60}, {-50, -60}, {-50, -50}, {-60, -50}, {-60, -60}})},
+		{Title: "zone 2", GeoPolygon: georm.NewPolygon([]geom.Coord{{-53, -53}, {-52, -53}, {-52, -52}, {-53, -52}, {-53, -53}})},
+		{Title: "zone 3", GeoPolygon: georm.NewPolygon([]geom.Coord{{-55, -55}, {-53, -55}, {-53, -53}, {-55, -53}, {-55, -55}})},
+	}
+
+	err := storage.AddAddresses(addresses...)
+	require.NoError(t, err)
+
+	for _, zone := range zones {
+		require.NoError(t, storage.AddZone(zone))
+	}
+
+	found, err := storage.FindZonesWithAddresses(zones[0].ID, zones[1].ID, zones[2].ID)
+	require.NoError(t, err)
+	require.Len(t, found, 3)
+
+	require.Equal(t, []Address{*addresses[0], *addresses[1]}, found[0].Addresses)
+	require.Equal(t, []Address{}, found[1].Addresses)
+	require.Equal(t, []Address{*addresses[1]}, found[2].Addresses)
+
+	// the same result as separate queries
+	for _, zone := range found {
+		inPolygon, err := storage.FindAddressesInPolygon(zone.GeoPolygon)
+		require.NoError(t, err)
+		require.ElementsMatch(t, inPolygon, zone.Addresses)
+	}
+}
+
+func TestSpatialJoinRoutesZones(t *testing.T) {
+	zones := []*Zone{
+		{Title: "join zone 1", GeoPolygon: georm.NewPolygon([]geom.Coord{{-160, 60}, {-150, 60}, {-150, 70}, {-160, 70}, {-160, 60}})},
+		{Title: "join zone 2", GeoPolygon: georm.NewPolygon([]geom.Coord{{-148, 60}, {-140, 60}, {-140, 70}, {-148, 70}, {-148, 60}})},
+	}
+
+	routes := []*Route{
+		{Title: "join route 1", GeoRoute: georm.NewLineString(geom.Coord{-155, 65}, geom.Coord{-145, 65})},
+		{Title: "join route 2", GeoRoute: georm.NewLineString(geom.Coord{-155, 50}, geom.Coord{-145, 50})},
+	}
+
+	for _, zone := range zones {
+		require.NoError(t, storage.AddZone(zone))
+	}
+
+	for _, route := range routes {
+		require.NoError(t, storage.AddRoute(route))
+	}
+
+	tx := storage.db.Where("routes.title LIKE ?", "join route %").Order("routes.id, zones.id")
+
+	pairs, err := georm.SpatialJoin(tx, &Route{}, &Zone{}, georm.Intersects).Find()
+	require.NoError(t, err)
+	require.Len(t, pairs, 2)
+
+	for i, pair := range pairs {
+		require.Equal(t, *routes[0], pair.Left)
+		require.Equal(t, zones[i].ID, pair.Right.ID)
+	}
+
+	pairs, err = georm.SpatialJoin(tx, &Route{}, &Zone{}, georm.Intersects).Left().Find()
+	require.NoError(t, err)
+	require.Len(t, pairs, 3)
+	require.Equal(t, *routes[1], pairs[2].Left)
+	require.Nil(t, pairs[2].Right)
+}
+
+func TestStorage_FindAddressesNear(t *testing.T) {
+	center := georm.NewPoint(30, -30)
+
+	addresses := []*Address{
+		{Address: "near address 1", GeoPoint: georm.NewPoint(30.004, -30)},
+		{Address: "near address 2", GeoPoint: georm.NewPoint(30, -30.004)},
+		{Address: "far address 1", GeoPoint: georm.NewPoint(30.01, -30)},
+		{Address: "far address 2", GeoPoint: georm.NewPoint(30.004, -30.004)},
+	}
+
+	err := storage.AddAddresses(addresses...)
+	require.NoError(t, err)
+
+	near, err := storage.FindAddressesNear(center, 500*georm.Meter)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Address{*addresses[0], *addresses[1]}, near)
+
+	// geodesic distance in Go agrees with the query
+	for _, address := range addresses {
+		distance, err := address.GeoPoint.GeodesicDistance(center)
+		require.NoError(t, err)
+
+		isNear := slices.ContainsFunc(near, func(a Address) bool { return a.ID == address.ID })
+		require.Equal(t, isNear, distance <= 500, address.Address)
+	}
 }
@@ -0,0 +1,43 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+
+	"github.com/ybru-tech/georm"
+	"github.com/ybru-tech/georm/index"
+)
+
+type IndexZone struct {
+	ID         uint `gorm:"primaryKey"`
+	Title      string
+	GeoPolygon georm.Polygon
+}
+
+func TestIndexLoad(t *testing.T) {
+	migrator := db.Migrator()
+
+	err := migrator.AutoMigrate(&IndexZone{})
+	require.NoError(t, err)
+
+	defer func() {
+		_ = migrator.DropTable(&IndexZone{})
+	}()
+
+	zones := []IndexZone{
+		{Title: "zone 1", GeoPolygon: georm.NewPolygon([]geom.Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}})},
+		{Title: "zone 2", GeoPolygon: georm.NewPolygon([]geom.Coord{{20, 20}, {30, 20}, {30, 30}, {20, 30}, {20, 20}})},
+	}
+
+	err = db.Create(&zones).Error
+	require.NoError(t, err)
+
+	tree, err := index.Load[uint, IndexZone](db, "GeoPolygon")
+	require.NoError(t, err)
+	require.Equal(t, 2, tree.Len())
+
+	require.Equal(t, []uint{zones[0].ID}, tree.Query(georm.NewPoint(5, 5), georm.Contains))
+	require.Equal(t, []uint{zones[1].ID}, tree.Nearest(georm.NewPoint(25, 40), 1))
+}
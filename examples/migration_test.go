@@ -51,6 +51,14 @@ func TestMigrate(t *testing.T) {
 			model:              TempTableWithGeometry[georm.GeometryCollection]{},
 			expectGeometryType: "geometry(GeometryCollection,4326)",
 		},
+		{
+			model:              TempTableWithGeometry[georm.Box2D]{},
+			expectGeometryType: "box2d",
+		},
+		{
+			model:              TempTableWithGeometry[georm.Box3D]{},
+			expectGeometryType: "box3d",
+		},
 	}
 
 	for _, test := range tests {
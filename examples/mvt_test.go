@@ -0,0 +1,62 @@
+package examples
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+
+	"github.com/ybru-tech/georm"
+	"github.com/ybru-tech/georm/mvt"
+)
+
+type TileZone struct {
+	ID         uint `gorm:"primaryKey"`
+	Title      string
+	GeoPolygon georm.Polygon
+}
+
+func TestMVTTile(t *testing.T) {
+	migrator := db.Migrator()
+
+	err := migrator.AutoMigrate(&TileZone{})
+	require.NoError(t, err)
+
+	defer func() {
+		_ = migrator.DropTable(&TileZone{})
+	}()
+
+	zone := TileZone{
+		Title: "zone",
+		GeoPolygon: georm.New(geom.NewPolygon(geom.XY).MustSetCoords(
+			[][]geom.Coord{{{11, 11}, {11, 15}, {15, 15}, {15, 11}, {11, 11}}},
+		).SetSRID(4326)),
+	}
+
+	err = db.Create(&zone).Error
+	require.NoError(t, err)
+
+	layer := mvt.Layer{Model: &TileZone{}, Geometry: "GeoPolygon", Attributes: []string{"ID", "Title"}, Simplify: 1}
+
+	// tile covering the zone
+	tile, err := mvt.Tile(db, layer, 0, 0, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, tile)
+
+	// tile outside the zone
+	tile, err = mvt.Tile(db, layer, 2, 0, 3)
+	require.NoError(t, err)
+	require.Empty(t, tile)
+
+	server := httptest.NewServer(mvt.Handler(db, layer))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/tiles/0/0/0.pbf")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, mvt.ContentType, resp.Header.Get("Content-Type"))
+}
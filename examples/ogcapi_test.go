@@ -0,0 +1,160 @@
+package examples
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ybru-tech/georm"
+	"github.com/ybru-tech/georm/ogcapi"
+)
+
+type FeatureAddress struct {
+	ID        uint `gorm:"primaryKey"`
+	Address   string
+	CreatedAt time.Time
+	GeoPoint  georm.Point
+}
+
+type featureCollection struct {
+	Features []struct {
+		ID         uint           `json:"id"`
+		Geometry   georm.Point    `json:"geometry"`
+		Properties map[string]any `json:"properties"`
+	} `json:"features"`
+	NumberMatched  int `json:"numberMatched"`
+	NumberReturned int `json:"numberReturned"`
+	Links          []struct {
+		Rel string `json:"rel"`
+	} `json:"links"`
+}
+
+func TestOGCAPIFeatures(t *testing.T) {
+	migrator := db.Migrator()
+
+	err := migrator.AutoMigrate(&FeatureAddress{})
+	require.NoError(t, err)
+
+	defer func() {
+		_ = migrator.DropTable(&FeatureAddress{})
+	}()
+
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	addresses := []*FeatureAddress{
+		{Address: "address 1", CreatedAt: created, GeoPoint: georm.NewPoint(12, 14)},
+		{Address: "address 2", CreatedAt: created, GeoPoint: georm.NewPoint(14, 14)},
+		{Address: "address 3", CreatedAt: created.AddDate(0, 1, 0), GeoPoint: georm.NewPoint(14, 12)},
+		{Address: "address 4", CreatedAt: created.AddDate(0, 1, 0), GeoPoint: georm.NewPoint(20, 20)},
+	}
+
+	err = db.Create(addresses).Error
+	require.NoError(t, err)
+
+	server, err := ogcapi.NewServer(db, ogcapi.Config{Title: "addresses"},
+		ogcapi.Collection{ID: "addresses", Model: &FeatureAddress{}, Datetime: "CreatedAt"},
+	)
+	require.NoError(t, err)
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	get := func(t *testing.T, path string, expectStatus int, dest any) {
+		t.Helper()
+
+		resp, err := http.Get(httpServer.URL + path)
+		require.NoError(t, err)
+
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		require.Equal(t, expectStatus, resp.StatusCode)
+
+		if dest != nil {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(dest))
+		}
+	}
+
+	tests := []struct {
+		Name          string
+		Query         string
+		ExpectIDs     []uint
+		ExpectMatched int
+		ExpectLinks   []string
+	}{
+		{
+			Name:          "all items",
+			Query:         "",
+			ExpectIDs:     []uint{addresses[0].ID, addresses[1].ID, addresses[2].ID, addresses[3].ID},
+			ExpectMatched: 4,
+			ExpectLinks:   []string{"self"},
+		},
+		{
+			Name:          "paging",
+			Query:         "?limit=2&offset=1",
+			ExpectIDs:     []uint{addresses[1].ID, addresses[2].ID},
+			ExpectMatched: 4,
+			ExpectLinks:   []string{"self", "next", "prev"},
+		},
+		{
+			Name:          "bbox",
+			Query:         "?bbox=11,11,15,15",
+			ExpectIDs:     []uint{addresses[0].ID, addresses[1].ID, addresses[2].ID},
+			ExpectMatched: 3,
+			ExpectLinks:   []string{"self"},
+		},
+		{
+			Name:          "bbox and datetime",
+			Query:         "?bbox=11,11,15,15&datetime=2024-01-15T00:00:00Z/..",
+			ExpectIDs:     []uint{addresses[2].ID},
+			ExpectMatched: 1,
+			ExpectLinks:   []string{"self"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var actual featureCollection
+			get(t, "/collections/addresses/items"+test.Query, http.StatusOK, &actual)
+
+			actualIDs := make([]uint, 0, len(actual.Features))
+			for _, feature := range actual.Features {
+				actualIDs = append(actualIDs, feature.ID)
+			}
+
+			actualLinks := make([]string, 0, len(actual.Links))
+			for _, link := range actual.Links {
+				actualLinks = append(actualLinks, link.Rel)
+			}
+
+			require.Equal(t, test.ExpectIDs, actualIDs)
+			require.Equal(t, test.ExpectMatched, actual.NumberMatched)
+			require.Equal(t, len(test.ExpectIDs), actual.NumberReturned)
+			require.Equal(t, test.ExpectLinks, actualLinks)
+		})
+	}
+
+	t.Run("item by id", func(t *testing.T) {
+		var actual struct {
+			ID         uint           `json:"id"`
+			Geometry   georm.Point    `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		}
+
+		get(t, "/collections/addresses/items/"+strconv.Itoa(int(addresses[0].ID)), http.StatusOK, &actual)
+
+		require.Equal(t, addresses[0].ID, actual.ID)
+		require.Equal(t, addresses[0].GeoPoint, actual.Geometry)
+		require.Equal(t, "address 1", actual.Properties["address"])
+	})
+
+	t.Run("item not found", func(t *testing.T) {
+		get(t, "/collections/addresses/items/0", http.StatusNotFound, nil)
+	})
+}
@@ -0,0 +1,39 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+
+	"github.com/ybru-tech/georm"
+)
+
+func TestOverlayAgreesWithPostGIS(t *testing.T) {
+	zone := georm.New[geom.T](georm.NewPolygon(
+		[]geom.Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		[]geom.Coord{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}},
+	).Geom)
+	city := georm.NewPolygon([]geom.Coord{{3, 3}, {15, 1}, {12, 12}, {3, 3}})
+
+	for function, overlay := range map[string]func(georm.Geometric) (georm.Geometry[geom.T], error){
+		"ST_Intersection":  zone.Intersection,
+		"ST_Union":         zone.Union,
+		"ST_Difference":    zone.Difference,
+		"ST_SymDifference": zone.SymDifference,
+	} {
+		var expected georm.Geometry[geom.T]
+
+		err := db.Raw("SELECT "+function+"(?::geometry, ?::geometry)", zone, city).Row().Scan(&expected)
+		require.NoError(t, err)
+
+		actual, err := overlay(city)
+		require.NoError(t, err)
+
+		difference, err := actual.SymDifference(expected)
+		require.NoError(t, err)
+
+		require.InDelta(t, expected.Area(), actual.Area(), 1e-9, function)
+		require.InDelta(t, 0, difference.Area(), 1e-9, function)
+	}
+}
@@ -0,0 +1,439 @@
+package georm
+
+import (
+	"errors"
+	"math"
+
+	"github.com/twpayne/go-geom"
+)
+
+var ErrNotGeographic = errors.New("geodesic measures require geographic SRID")
+
+// WGS 84 ellipsoid
+const (
+	wgs84A = 6378137.0
+	wgs84F = 1 / 298.257223563
+	wgs84B = wgs84A * (1 - wgs84F)
+
+	// meanRadius of the ellipsoid, used when Vincenty formulae do not converge
+	meanRadius = (2*wgs84A + wgs84B) / 3
+)
+
+// GeodesicArea returns area of polygons on WGS 84 ellipsoid in square meters,
+// geometry coordinates must be lon/lat of one of GeographicSRIDs
+func (g Geometry[T]) GeodesicArea() (float64, error) {
+	if err := checkGeographic(g.Geom); err != nil {
+		return 0, err
+	}
+
+	if isNil(g.Geom) {
+		return 0, nil
+	}
+
+	return measure(g.Geom, func(g geom.T) float64 {
+		p, ok := g.(*geom.Polygon)
+		if !ok {
+			return 0
+		}
+
+		return polygonArea(p, func(ring []float64) float64 { return ellipsoidRingArea(ring, p.Stride()) })
+	}), nil
+}
+
+// GeodesicLength returns length of lines on WGS 84 ellipsoid in meters
+func (g Geometry[T]) GeodesicLength() (float64, error) {
+	if err := checkGeographic(g.Geom); err != nil {
+		return 0, err
+	}
+
+	if isNil(g.Geom) {
+		return 0, nil
+	}
+
+	return measure(g.Geom, func(g geom.T) float64 {
+		l, ok := g.(*geom.LineString)
+		if !ok {
+			return 0
+		}
+
+		return lineLength(l.FlatCoords(), l.Stride(), geodesicDistance)
+	}), nil
+}
+
+// GeodesicPerimeter returns length of polygon rings on WGS 84 ellipsoid in meters
+func (g Geometry[T]) GeodesicPerimeter() (float64, error) {
+	if err := checkGeographic(g.Geom); err != nil {
+		return 0, err
+	}
+
+	if isNil(g.Geom) {
+		return 0, nil
+	}
+
+	return measure(g.Geom, func(g geom.T) float64 {
+		p, ok := g.(*geom.Polygon)
+		if !ok {
+			return 0
+		}
+
+		return polygonPerimeter(p, geodesicDistance)
+	}), nil
+}
+
+// GeodesicDistance returns distance in meters on WGS 84 ellipsoid from the point
+// to the closest point of geometry, 0 when the point lies inside a polygon,
+// NaN when either geometry is empty
+func (g Geometry[T]) GeodesicDistance(p Point) (float64, error) {
+	if err := checkGeographic(g.Geom); err != nil {
+		return 0, err
+	}
+
+	if isNil(g.Geom) || isNil(p.Geom) || p.Geom.Empty() {
+		return math.NaN(), nil
+	}
+
+	return pointDistance(g.Geom, p.Geom.Coords(), geodesicDistance, geodesicSegmentDistance), nil
+}
+
+// GeodesicCentroid returns center of mass of geometry on the sphere, as PostGIS
+// ST_Centroid for geography: parts are weighted by spherical area or length
+func (g Geometry[T]) GeodesicCentroid() (Point, error) {
+	if err := checkGeographic(g.Geom); err != nil {
+		return Point{}, err
+	}
+
+	if isNil(g.Geom) {
+		return New(geom.NewPointEmpty(geom.XY)), nil
+	}
+
+	var sum sphereCentroidSum
+	eachPart(g.Geom, sum.add)
+
+	centroid := geom.NewPointEmpty(geom.XY).SetSRID(g.Geom.SRID())
+
+	if norm := math.Sqrt(sum.v.dot(sum.v)); norm > 0 {
+		v := sum.v.scale(1 / norm)
+		centroid.MustSetCoords(geom.Coord{math.Atan2(v[1], v[0]) * 180 / math.Pi, math.Asin(v[2]) * 180 / math.Pi})
+	}
+
+	return New(centroid), nil
+}
+
+func checkGeographic(g geom.T) error {
+	if isNil(g) {
+		return nil
+	}
+
+	srid := g.SRID()
+	if srid == 0 {
+		srid = SRID
+	}
+
+	if !GeographicSRIDs[srid] {
+		return ErrNotGeographic
+	}
+
+	return nil
+}
+
+func radians(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+func degrees(radians float64) float64 { return radians * 180 / math.Pi }
+
+// geodesicDistance returns distance between lon/lat coordinates by Vincenty
+// inverse formula, nearly antipodal points fall back to the sphere
+func geodesicDistance(c1, c2 []float64) float64 {
+	if c1[0] == c2[0] && c1[1] == c2[1] {
+		return 0
+	}
+
+	var (
+		l  = radians(c2[0] - c1[0])
+		u1 = math.Atan((1 - wgs84F) * math.Tan(radians(c1[1])))
+		u2 = math.Atan((1 - wgs84F) * math.Tan(radians(c2[1])))
+
+		sinU1, cosU1 = math.Sincos(u1)
+		sinU2, cosU2 = math.Sincos(u2)
+
+		lambda = l
+	)
+
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+
+		sinSigma := math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return 0
+		}
+
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha := 1 - sinAlpha*sinAlpha
+
+		cos2SigmaM := 0.0
+		if cos2Alpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+
+		c := wgs84F / 16 * cos2Alpha * (4 + wgs84F*(4-3*cos2Alpha))
+
+		prev := lambda
+		lambda = l + (1-c)*wgs84F*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda-prev) < 1e-12 {
+			u2 := cos2Alpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+			a := 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+			b := u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+			deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+				b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+			return wgs84B * a * (sigma - deltaSigma)
+		}
+	}
+
+	return sphereDistance(c1, c2)
+}
+
+// sphereDistance returns great circle distance by haversine formula
+func sphereDistance(c1, c2 []float64) float64 {
+	var (
+		lat1, lat2 = radians(c1[1]), radians(c2[1])
+		dLat       = lat2 - lat1
+		dLon       = radians(c2[0] - c1[0])
+	)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * meanRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// geodesicSegmentDistance finds the closest point of the segment by golden
+// section search along the great circle between segment ends
+func geodesicSegmentDistance(p, start, end []float64) float64 {
+	var (
+		v1, v2 = toVector(start), toVector(end)
+		at     = func(t float64) float64 { return geodesicDistance(p, fromVector(slerp(v1, v2, t))) }
+
+		ratio  = (math.Sqrt(5) - 1) / 2
+		lo, hi = 0.0, 1.0
+	)
+
+	for i := 0; i < 60 && hi-lo > 1e-12; i++ {
+		m1, m2 := hi-ratio*(hi-lo), lo+ratio*(hi-lo)
+		if at(m1) < at(m2) {
+			hi = m2
+		} else {
+			lo = m1
+		}
+	}
+
+	return math.Min(at((lo+hi)/2), math.Min(geodesicDistance(p, start), geodesicDistance(p, end)))
+}
+
+// ellipsoidRingArea returns absolute area of the ring on the ellipsoid, the ring is
+// mapped to the authalic sphere of the same area, where edges are great circles
+func ellipsoidRingArea(ring []float64, stride int) float64 {
+	var excess float64
+
+	for i := stride; i < len(ring); i += stride {
+		var (
+			lon1, lon2 = radians(ring[i-stride]), radians(ring[i])
+			t1         = math.Tan(authalicLatitude(radians(ring[i-stride+1])) / 2)
+			t2         = math.Tan(authalicLatitude(radians(ring[i+1])) / 2)
+		)
+
+		dLon := lon2 - lon1
+		switch {
+		case dLon > math.Pi:
+			dLon -= 2 * math.Pi
+		case dLon < -math.Pi:
+			dLon += 2 * math.Pi
+		}
+
+		excess += 2 * math.Atan2(math.Tan(dLon/2)*(t1+t2), 1+t1*t2)
+	}
+
+	return math.Abs(excess) * authalicRadius * authalicRadius
+}
+
+var (
+	eccentricity   = math.Sqrt(wgs84F * (2 - wgs84F))
+	authalicQP     = authalicQ(1)
+	authalicRadius = wgs84A * math.Sqrt(authalicQP/2)
+)
+
+func authalicQ(sinPhi float64) float64 {
+	e, e2 := eccentricity, eccentricity*eccentricity
+
+	return (1 - e2) * (sinPhi/(1-e2*sinPhi*sinPhi) - 1/(2*e)*math.Log((1-e*sinPhi)/(1+e*sinPhi)))
+}
+
+func authalicLatitude(phi float64) float64 {
+	return math.Asin(math.Max(-1, math.Min(1, authalicQ(math.Sin(phi))/authalicQP)))
+}
+
+type vector [3]float64
+
+func toVector(c []float64) vector {
+	sinLon, cosLon := math.Sincos(radians(c[0]))
+	sinLat, cosLat := math.Sincos(radians(c[1]))
+
+	return vector{cosLat * cosLon, cosLat * sinLon, sinLat}
+}
+
+func fromVector(v vector) []float64 {
+	return []float64{math.Atan2(v[1], v[0]) * 180 / math.Pi, math.Atan2(v[2], math.Hypot(v[0], v[1])) * 180 / math.Pi}
+}
+
+func (v vector) dot(w vector) float64 { return v[0]*w[0] + v[1]*w[1] + v[2]*w[2] }
+
+func (v vector) cross(w vector) vector {
+	return vector{v[1]*w[2] - v[2]*w[1], v[2]*w[0] - v[0]*w[2], v[0]*w[1] - v[1]*w[0]}
+}
+
+func (v vector) add(w vector) vector { return vector{v[0] + w[0], v[1] + w[1], v[2] + w[2]} }
+
+func (v vector) scale(k float64) vector { return vector{v[0] * k, v[1] * k, v[2] * k} }
+
+// slerp interpolates unit vectors along the great circle
+func slerp(v1, v2 vector, t float64) vector {
+	omega := math.Acos(math.Max(-1, math.Min(1, v1.dot(v2))))
+	if omega < 1e-15 {
+		return v1
+	}
+
+	sinOmega := math.Sin(omega)
+
+	return v1.scale(math.Sin((1-t)*omega) / sinOmega).add(v2.scale(math.Sin(t*omega) / sinOmega))
+}
+
+// sphereCentroidSum accumulates weighted unit vectors of the highest dimension parts
+type sphereCentroidSum struct {
+	dimension int
+	v         vector
+}
+
+func (s *sphereCentroidSum) push(dimension int, v vector) {
+	if dimension < s.dimension {
+		return
+	}
+
+	if dimension > s.dimension {
+		*s = sphereCentroidSum{dimension: dimension}
+	}
+
+	s.v = s.v.add(v)
+}
+
+func (s *sphereCentroidSum) add(g geom.T) {
+	flatCoords, stride := g.FlatCoords(), g.Stride()
+	if len(flatCoords) == 0 {
+		return
+	}
+
+	switch g := g.(type) {
+	case *geom.Point:
+		s.push(0, toVector(flatCoords))
+	case *geom.LineString:
+		for i := stride; i < len(flatCoords); i += stride {
+			v1, v2 := toVector(flatCoords[i-stride:i]), toVector(flatCoords[i:i+stride])
+			if mid := v1.add(v2); mid.dot(mid) > 0 {
+				length := geodesicDistance(flatCoords[i-stride:i], flatCoords[i:i+stride])
+				s.push(1, mid.scale(length/math.Sqrt(mid.dot(mid))))
+			}
+		}
+	case *geom.Polygon:
+		for i := 0; i < g.NumLinearRings(); i++ {
+			v, area := sphereRingCentroid(g.LinearRing(i).FlatCoords(), stride)
+			if i > 0 {
+				v = v.scale(-1)
+			}
+
+			if area != 0 {
+				s.push(2, v)
+			}
+		}
+	}
+}
+
+// sphereRingCentroid sums centroids of triangle fan of the ring weighted by
+// their signed spherical areas, orientation of the ring is normalized
+func sphereRingCentroid(ring []float64, stride int) (vector, float64) {
+	var (
+		a    = toVector(ring)
+		sum  vector
+		area float64
+	)
+
+	for i := 2 * stride; i < len(ring); i += stride {
+		b, c := toVector(ring[i-stride:i]), toVector(ring[i:i+stride])
+
+		// Van Oosterom and Strackee formula of the solid angle
+		e := 2 * math.Atan2(a.dot(b.cross(c)), 1+a.dot(b)+b.dot(c)+c.dot(a))
+
+		center := a.add(b).add(c)
+		if norm := math.Sqrt(center.dot(center)); norm > 0 {
+			sum = sum.add(center.scale(e / norm))
+			area += e
+		}
+	}
+
+	if area < 0 {
+		return sum.scale(-1), -area
+	}
+
+	return sum, area
+}
+
+// geodesicDestination returns lon/lat coordinate at the distance in meters
+// from c along the azimuth in degrees by Vincenty direct formula,
+// longitude is not normalized to [-180, 180]
+func geodesicDestination(c []float64, azimuth, distance float64) []float64 {
+	var (
+		sinAlpha1, cosAlpha1 = math.Sincos(radians(azimuth))
+
+		tanU1 = (1 - wgs84F) * math.Tan(radians(c[1]))
+		cosU1 = 1 / math.Sqrt(1+tanU1*tanU1)
+		sinU1 = tanU1 * cosU1
+
+		sigma1    = math.Atan2(tanU1, cosAlpha1)
+		sinAlpha  = cosU1 * sinAlpha1
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+
+		u2 = cos2Alpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+		a  = 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+		b  = u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+
+		sigma                        = distance / (wgs84B * a)
+		sinSigma, cosSigma, cos2SigM float64
+	)
+
+	for i := 0; i < 200; i++ {
+		cos2SigM = math.Cos(2*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sincos(sigma)
+
+		deltaSigma := b * sinSigma * (cos2SigM + b/4*(cosSigma*(-1+2*cos2SigM*cos2SigM)-
+			b/6*cos2SigM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigM*cos2SigM)))
+
+		prev := sigma
+		sigma = distance/(wgs84B*a) + deltaSigma
+
+		if math.Abs(sigma-prev) < 1e-12 {
+			break
+		}
+	}
+
+	sinSigma, cosSigma = math.Sincos(sigma)
+	cos2SigM = math.Cos(2*sigma1 + sigma)
+
+	tmp := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	lat := math.Atan2(sinU1*cosSigma+cosU1*sinSigma*cosAlpha1, (1-wgs84F)*math.Hypot(sinAlpha, tmp))
+	lambda := math.Atan2(sinSigma*sinAlpha1, cosU1*cosSigma-sinU1*sinSigma*cosAlpha1)
+
+	cc := wgs84F / 16 * cos2Alpha * (4 + wgs84F*(4-3*cos2Alpha))
+	l := lambda - (1-cc)*wgs84F*sinAlpha*(sigma+cc*sinSigma*(cos2SigM+cc*cosSigma*(-1+2*cos2SigM*cos2SigM)))
+
+	return []float64{c[0] + l*180/math.Pi, lat * 180 / math.Pi}
+}
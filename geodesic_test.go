@@ -0,0 +1,112 @@
+package georm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+// Expected values are PostGIS results for geography (WGS 84 spheroid)
+
+func TestGeometryGeodesicMeasures(t *testing.T) {
+	square := NewPolygon([]geom.Coord{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}})
+
+	// SELECT ST_Area('POLYGON((0 0,1 0,1 1,0 1,0 0))'::geography)
+	area, err := square.GeodesicArea()
+	require.NoError(t, err)
+	assert.InEpsilon(t, 12308778361.469, area, 1e-6)
+
+	// SELECT ST_Perimeter('POLYGON((0 0,1 0,1 1,0 1,0 0))'::geography)
+	perimeter, err := square.GeodesicPerimeter()
+	require.NoError(t, err)
+	assert.InDelta(t, 443770.917, perimeter, 0.01)
+
+	// SELECT ST_Length('LINESTRING(0 0,1 0)'::geography)
+	length, err := NewLineString(geom.Coord{0, 0}, geom.Coord{1, 0}).GeodesicLength()
+	require.NoError(t, err)
+	assert.InDelta(t, 111319.491, length, 0.001)
+
+	// hole is subtracted
+	withHole := NewPolygon(
+		[]geom.Coord{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}},
+		[]geom.Coord{{0.25, 0.25}, {0.25, 0.75}, {0.75, 0.75}, {0.75, 0.25}, {0.25, 0.25}},
+	)
+	holeArea, err := withHole.GeodesicArea()
+	require.NoError(t, err)
+	assert.InEpsilon(t, area*0.75, holeArea, 1e-4)
+
+	centroid, err := square.GeodesicCentroid()
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, centroid.X(), 1e-6)
+	assert.InDelta(t, 0.5, centroid.Y(), 1e-4)
+}
+
+func TestGeodesicDistance(t *testing.T) {
+	// Vincenty's test case: Flinders Peak to Buninyong
+	flinders := []float64{144 + 25/60.0 + 29.52440/3600, -(37 + 57/60.0 + 3.72030/3600)}
+	buninyong := []float64{143 + 55/60.0 + 35.38390/3600, -(37 + 39/60.0 + 10.15610/3600)}
+	assert.InDelta(t, 54972.271, geodesicDistance(flinders, buninyong), 0.001)
+
+	// nearly antipodal points
+	assert.InEpsilon(t, 20003931, geodesicDistance([]float64{0, 0}, []float64{179.9, 0.1}), 1e-3)
+
+	equator := NewLineString(geom.Coord{0, 0}, geom.Coord{2, 0})
+
+	// SELECT ST_Distance('LINESTRING(0 0,2 0)'::geography, 'POINT(1 1)'::geography)
+	d, err := equator.GeodesicDistance(NewPoint(1, 1))
+	require.NoError(t, err)
+	assert.InDelta(t, 110574.389, d, 0.01)
+
+	d, err = NewPolygon([]geom.Coord{{0, 0}, {1, 0}, {1, 1}, {0, 0}}).GeodesicDistance(NewPoint(0.9, 0.1))
+	require.NoError(t, err)
+	assert.Zero(t, d)
+}
+
+func TestGeometryGeodesicExpectNotGeographic(t *testing.T) {
+	projected := New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{4187591, 7509137}).SetSRID(3857))
+
+	_, err := projected.GeodesicArea()
+	require.ErrorIs(t, err, ErrNotGeographic)
+
+	_, err = projected.GeodesicDistance(NewPoint(0, 0))
+	require.ErrorIs(t, err, ErrNotGeographic)
+}
+
+func TestGeometryGeodesicCentroidEmpty(t *testing.T) {
+	var empty Polygon
+
+	centroid, err := empty.GeodesicCentroid()
+	require.NoError(t, err)
+	assert.True(t, centroid.Geom.Empty())
+}
+
+func TestGeometryGeodesicMeasuresZero(t *testing.T) {
+	var (
+		polygon Polygon
+		line    LineString
+		point   Point
+	)
+
+	area, err := polygon.GeodesicArea()
+	require.NoError(t, err)
+	assert.Zero(t, area)
+
+	perimeter, err := polygon.GeodesicPerimeter()
+	require.NoError(t, err)
+	assert.Zero(t, perimeter)
+
+	length, err := line.GeodesicLength()
+	require.NoError(t, err)
+	assert.Zero(t, length)
+
+	d, err := point.GeodesicDistance(NewPoint(0, 0))
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(d))
+
+	d, err = NewPoint(0, 0).GeodesicDistance(point)
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(d))
+}
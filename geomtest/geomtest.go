@@ -0,0 +1,77 @@
+// Package geomtest provides testify compatible assertions for georm geometries
+package geomtest
+
+import (
+	"fmt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twpayne/go-geom/encoding/wkt"
+
+	"github.com/ybru-tech/georm"
+)
+
+type tHelper interface {
+	Helper()
+}
+
+// Equal asserts that geometries are equal within tolerance, see georm.Geometry.Equal
+func Equal(t assert.TestingT, expected, actual georm.Geometric, tolerance float64, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if georm.New(expected.GeomT()).Equal(actual, tolerance) {
+		return true
+	}
+
+	return fail(t, fmt.Sprintf("Geometries are not equal within %g", tolerance), expected, actual, msgAndArgs...)
+}
+
+// EqualTopo asserts that geometries cover the same set of points, see georm.Geometry.EqualTopo
+func EqualTopo(t assert.TestingT, expected, actual georm.Geometric, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if georm.New(expected.GeomT()).EqualTopo(actual) {
+		return true
+	}
+
+	return fail(t, "Geometries are not topologically equal", expected, actual, msgAndArgs...)
+}
+
+// EqualExact asserts that geometries are exactly equal, see georm.Geometry.EqualExact
+func EqualExact(t assert.TestingT, expected, actual georm.Geometric, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if georm.New(expected.GeomT()).EqualExact(actual) {
+		return true
+	}
+
+	return fail(t, "Geometries are not exactly equal", expected, actual, msgAndArgs...)
+}
+
+func fail(t assert.TestingT, message string, expected, actual georm.Geometric, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return assert.Fail(t, fmt.Sprintf("%s:\nexpected: %s\nactual  : %s", message, ewkt(expected), ewkt(actual)), msgAndArgs...)
+}
+
+// ewkt formats geometry as EWKT to show SRID differences
+func ewkt(g georm.Geometric) string {
+	geometry := g.GeomT()
+	if geometry == nil {
+		return "<nil>"
+	}
+
+	text, err := wkt.Marshal(geometry)
+	if err != nil {
+		return fmt.Sprintf("%T", geometry)
+	}
+
+	return fmt.Sprintf("SRID=%d;%s", geometry.SRID(), text)
+}
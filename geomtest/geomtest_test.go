@@ -0,0 +1,91 @@
+package geomtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twpayne/go-geom"
+
+	"github.com/ybru-tech/georm"
+)
+
+type mockT struct {
+	errors []string
+}
+
+func (m *mockT) Errorf(format string, args ...any) {
+	m.errors = append(m.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	var (
+		square  = georm.NewPolygon([]geom.Coord{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}})
+		rotated = georm.NewPolygon([]geom.Coord{{1, 1}, {0, 1}, {0, 0}, {1, 0}, {1, 1}})
+		moved   = georm.NewPolygon([]geom.Coord{{0, 0}, {1, 0}, {1, 1.5}, {0, 1}, {0, 0}})
+	)
+
+	tests := []struct {
+		Name   string
+		Assert func(t assert.TestingT) bool
+		Expect bool
+	}{
+		{
+			Name:   "equal",
+			Assert: func(t assert.TestingT) bool { return Equal(t, square, rotated, 1e-9) },
+			Expect: true,
+		},
+		{
+			Name:   "not equal",
+			Assert: func(t assert.TestingT) bool { return Equal(t, square, moved, 0.1) },
+			Expect: false,
+		},
+		{
+			Name:   "equal topo",
+			Assert: func(t assert.TestingT) bool { return EqualTopo(t, square, square.ForceRHR()) },
+			Expect: true,
+		},
+		{
+			Name:   "not equal topo",
+			Assert: func(t assert.TestingT) bool { return EqualTopo(t, square, moved) },
+			Expect: false,
+		},
+		{
+			Name:   "equal exact",
+			Assert: func(t assert.TestingT) bool { return EqualExact(t, square, square) },
+			Expect: true,
+		},
+		{
+			Name:   "not equal exact",
+			Assert: func(t assert.TestingT) bool { return EqualExact(t, square, rotated) },
+			Expect: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			m := &mockT{}
+
+			assert.Equal(t, test.Expect, test.Assert(m))
+
+			if test.Expect {
+				assert.Empty(t, m.errors)
+				return
+			}
+
+			assert.Len(t, m.errors, 1)
+			assert.Contains(t, m.errors[0], "SRID=4326;POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))")
+		})
+	}
+}
+
+func TestAssertionsNil(t *testing.T) {
+	m := &mockT{}
+
+	assert.True(t, EqualExact(m, georm.Point{}, georm.Point{}))
+	assert.False(t, Equal(m, georm.Point{}, georm.NewPoint(1, 2), 0, "point %d", 1))
+
+	assert.Len(t, m.errors, 1)
+	assert.Contains(t, m.errors[0], "expected: <nil>")
+	assert.Contains(t, m.errors[0], "point 1")
+}
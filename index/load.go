@@ -0,0 +1,140 @@
+package index
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+
+	"gorm.io/gorm"
+
+	"github.com/ybru-tech/georm"
+)
+
+var (
+	ErrFieldNotFound = errors.New("field not found in model")
+	ErrNotGeometry   = errors.New("field is not a georm geometry")
+	ErrNoPrimaryKey  = errors.New("model has no primary key")
+	ErrUnexpectedKey = errors.New("unexpected primary key type")
+)
+
+// Build returns RTree bulk loaded with geometries by Sort-Tile-Recursive packing,
+// which gives better query performance than inserting geometries one by one
+func Build[K comparable](geometries map[K]georm.Geometric) *RTree[K] {
+	t := New[K]()
+
+	var indexed []*item[K]
+
+	for key, geometry := range geometries {
+		it := &item[K]{key: key, geometry: geometry, box: boundsOf(geometry)}
+		t.items[key] = it
+
+		if !it.box.empty() {
+			indexed = append(indexed, it)
+		}
+	}
+
+	if len(indexed) == 0 {
+		return t
+	}
+
+	leaves := packSlices(indexed, func(it *item[K]) rect { return it.box }, func(items []*item[K]) *node[K] {
+		return &node[K]{leaf: true, items: items}
+	})
+
+	nodes := leaves
+	for len(nodes) > 1 {
+		nodes = packSlices(nodes, func(n *node[K]) rect { return n.box }, func(children []*node[K]) *node[K] {
+			return &node[K]{children: children}
+		})
+	}
+
+	t.root = nodes[0]
+
+	return t
+}
+
+// packSlices groups entries into nodes of up to maxEntries: entries sorted by x are cut
+// into vertical slices, entries of a slice sorted by y are cut into nodes
+func packSlices[E any, K comparable](entries []E, box func(E) rect, newNode func([]E) *node[K]) []*node[K] {
+	var (
+		nodeCount  = int(math.Ceil(float64(len(entries)) / maxEntries))
+		sliceCount = int(math.Ceil(math.Sqrt(float64(nodeCount))))
+		sliceSize  = sliceCount * maxEntries
+		nodes      = make([]*node[K], 0, nodeCount)
+	)
+
+	centerX := func(e E) float64 { b := box(e); return b.minX + b.maxX }
+	centerY := func(e E) float64 { b := box(e); return b.minY + b.maxY }
+
+	slices.SortFunc(entries, func(a, b E) int { return compareFloat(centerX(a), centerX(b)) })
+
+	for start := 0; start < len(entries); start += sliceSize {
+		slice := entries[start:min(start+sliceSize, len(entries))]
+		slices.SortFunc(slice, func(a, b E) int { return compareFloat(centerY(a), centerY(b)) })
+
+		for i := 0; i < len(slice); i += maxEntries {
+			n := newNode(slices.Clone(slice[i:min(i+maxEntries, len(slice))]))
+			n.updateBox()
+			nodes = append(nodes, n)
+		}
+	}
+
+	return nodes
+}
+
+// Load finds all rows of model M with db, which may be filtered by scopes and conditions,
+// and builds RTree of the geometry field keyed by primary key of type K, e.g.
+//
+//	zones, err := index.Load[uint, Zone](db, "GeoPolygon")
+func Load[K comparable, M any](db *gorm.DB, geometryField string) (*RTree[K], error) {
+	var rows []M
+
+	tx := db.Find(&rows)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(M)); err != nil {
+		return nil, err
+	}
+
+	primary := stmt.Schema.PrioritizedPrimaryField
+	if primary == nil {
+		return nil, ErrNoPrimaryKey
+	}
+
+	field := stmt.Schema.LookUpField(geometryField)
+	if field == nil {
+		return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, geometryField)
+	}
+
+	geometries := make(map[K]georm.Geometric, len(rows))
+
+	for i := range rows {
+		rv := reflect.ValueOf(&rows[i]).Elem()
+
+		keyValue, _ := primary.ValueOf(tx.Statement.Context, rv)
+
+		key, ok := keyValue.(K)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrUnexpectedKey, keyValue)
+		}
+
+		geometryValue, _ := field.ValueOf(tx.Statement.Context, rv)
+		if v := reflect.ValueOf(geometryValue); v.Kind() == reflect.Pointer && v.IsNil() {
+			continue
+		}
+
+		geometry, ok := geometryValue.(georm.Geometric)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotGeometry, geometryField)
+		}
+
+		geometries[key] = geometry
+	}
+
+	return Build(geometries), nil
+}
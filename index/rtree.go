@@ -0,0 +1,525 @@
+package index
+
+import (
+	"container/heap"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/twpayne/go-geom"
+
+	"github.com/ybru-tech/georm"
+)
+
+const (
+	maxEntries = 16
+	minEntries = maxEntries * 2 / 5
+)
+
+// RTree is an in-memory spatial index of geometries keyed by K, usually primary key
+// of the model. It is safe for concurrent use, writers block readers
+type RTree[K comparable] struct {
+	mu    sync.RWMutex
+	root  *node[K]
+	items map[K]*item[K]
+}
+
+type item[K comparable] struct {
+	key      K
+	geometry georm.Geometric
+	box      rect
+}
+
+type node[K comparable] struct {
+	box      rect
+	leaf     bool
+	children []*node[K]
+	items    []*item[K]
+}
+
+// New returns empty RTree
+func New[K comparable]() *RTree[K] {
+	return &RTree[K]{root: &node[K]{leaf: true}, items: map[K]*item[K]{}}
+}
+
+// Len returns number of geometries in the tree
+func (t *RTree[K]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return len(t.items)
+}
+
+// Get returns geometry stored by the key
+func (t *RTree[K]) Get(key K) (georm.Geometric, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	it, ok := t.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	return it.geometry, true
+}
+
+// Insert adds geometry by the key, geometry stored by the same key is replaced
+func (t *RTree[K]) Insert(key K, geometry georm.Geometric) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.delete(key)
+
+	it := &item[K]{key: key, geometry: geometry, box: boundsOf(geometry)}
+	t.items[key] = it
+
+	if !it.box.empty() {
+		t.insert(it)
+	}
+}
+
+// Delete removes geometry by the key, reports whether it was in the tree
+func (t *RTree[K]) Delete(key K) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.delete(key)
+}
+
+// Search returns keys of geometries which bounding boxes intersect the box,
+// box with MinX > MaxX crosses the antimeridian
+func (t *RTree[K]) Search(box georm.Box2D) []K {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var (
+		keys  []K
+		boxes = box.SplitAntimeridian()
+		seen  = map[K]bool{}
+	)
+
+	for _, b := range boxes {
+		t.search(t.root, rectOf(b), func(it *item[K]) {
+			// geometries may be found on both sides of the antimeridian
+			if len(boxes) > 1 {
+				if seen[it.key] {
+					return
+				}
+
+				seen[it.key] = true
+			}
+
+			keys = append(keys, it.key)
+		})
+	}
+
+	return keys
+}
+
+// Query returns keys of geometries for which predicate(geometry, g) is true,
+// candidates found by bounding boxes are refined by the exact predicate,
+// e.g. Query(point, georm.Contains) returns zones containing the point
+func (t *RTree[K]) Query(g georm.Geometric, predicate georm.Predicate) []K {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	box := boundsOf(g)
+
+	var keys []K
+
+	filter := func(it *item[K]) {
+		if predicate.Eval(it.geometry, g) {
+			keys = append(keys, it.key)
+		}
+	}
+
+	if predicate.Name() == georm.Disjoint.Name() {
+		// disjoint geometries are outside of the box, check all of them
+		for _, it := range t.items {
+			filter(it)
+		}
+
+		return keys
+	}
+
+	if !box.empty() {
+		t.search(t.root, box, filter)
+	}
+
+	return keys
+}
+
+// Nearest returns keys of up to n geometries closest to the point by planar distance,
+// ordered by distance
+func (t *RTree[K]) Nearest(p georm.Point, n int) []K {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if n <= 0 || p.Geom == nil || p.Geom.Empty() {
+		return nil
+	}
+
+	var (
+		x, y  = p.Geom.X(), p.Geom.Y()
+		queue = &nearestQueue[K]{}
+		keys  []K
+	)
+
+	heap.Push(queue, nearestEntry[K]{node: t.root, distance: t.root.box.distance(x, y)})
+
+	for queue.Len() > 0 && len(keys) < n {
+		entry := heap.Pop(queue).(nearestEntry[K])
+
+		switch {
+		case entry.node != nil:
+			for _, child := range entry.node.children {
+				heap.Push(queue, nearestEntry[K]{node: child, distance: child.box.distance(x, y)})
+			}
+
+			for _, it := range entry.node.items {
+				heap.Push(queue, nearestEntry[K]{item: it, distance: it.box.distance(x, y)})
+			}
+		case !entry.exact:
+			// box distance is a lower bound, requeue with exact distance
+			exact := georm.New(entry.item.geometry.GeomT()).Distance(p)
+			heap.Push(queue, nearestEntry[K]{item: entry.item, distance: exact, exact: true})
+		default:
+			keys = append(keys, entry.item.key)
+		}
+	}
+
+	return keys
+}
+
+func (t *RTree[K]) search(n *node[K], box rect, fn func(*item[K])) {
+	if !n.box.intersects(box) {
+		return
+	}
+
+	for _, child := range n.children {
+		t.search(child, box, fn)
+	}
+
+	for _, it := range n.items {
+		if it.box.intersects(box) {
+			fn(it)
+		}
+	}
+}
+
+func (t *RTree[K]) insert(it *item[K]) {
+	path := []*node[K]{t.root}
+
+	n := t.root
+	for !n.leaf {
+		n = chooseSubtree(n, it.box)
+		path = append(path, n)
+	}
+
+	n.items = append(n.items, it)
+
+	t.adjust(path)
+}
+
+// adjust updates boxes along the path from root to leaf and splits overflowed nodes
+func (t *RTree[K]) adjust(path []*node[K]) {
+	for i := len(path) - 1; i >= 0; i-- {
+		n := path[i]
+		n.updateBox()
+
+		if n.size() <= maxEntries {
+			continue
+		}
+
+		sibling := n.split()
+
+		if i == 0 {
+			t.root = &node[K]{children: []*node[K]{n, sibling}}
+			t.root.updateBox()
+
+			return
+		}
+
+		path[i-1].children = append(path[i-1].children, sibling)
+	}
+}
+
+func (t *RTree[K]) delete(key K) bool {
+	it, ok := t.items[key]
+	if !ok {
+		return false
+	}
+
+	delete(t.items, key)
+
+	if it.box.empty() {
+		return true
+	}
+
+	path := findLeaf(t.root, it, nil)
+	if path == nil {
+		return true
+	}
+
+	leaf := path[len(path)-1]
+	leaf.items = slices.DeleteFunc(leaf.items, func(other *item[K]) bool { return other == it })
+
+	t.condense(path)
+
+	return true
+}
+
+// condense removes underflowed nodes along the path and reinserts their items
+func (t *RTree[K]) condense(path []*node[K]) {
+	var orphans []*item[K]
+
+	for i := len(path) - 1; i > 0; i-- {
+		n, parent := path[i], path[i-1]
+
+		if n.size() < minEntries {
+			parent.children = slices.DeleteFunc(parent.children, func(child *node[K]) bool { return child == n })
+			orphans = n.collect(orphans)
+		} else {
+			n.updateBox()
+		}
+	}
+
+	t.root.updateBox()
+
+	for !t.root.leaf && len(t.root.children) == 1 {
+		t.root = t.root.children[0]
+	}
+
+	if !t.root.leaf && len(t.root.children) == 0 {
+		t.root = &node[K]{leaf: true}
+	}
+
+	for _, it := range orphans {
+		t.insert(it)
+	}
+}
+
+func findLeaf[K comparable](n *node[K], it *item[K], path []*node[K]) []*node[K] {
+	if !n.box.contains(it.box) {
+		return nil
+	}
+
+	path = append(path, n)
+
+	if n.leaf {
+		if slices.Contains(n.items, it) {
+			return path
+		}
+
+		return nil
+	}
+
+	for _, child := range n.children {
+		if found := findLeaf(child, it, path); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+func chooseSubtree[K comparable](n *node[K], box rect) *node[K] {
+	var (
+		best        *node[K]
+		bestEnlarge = math.Inf(1)
+		bestArea    = math.Inf(1)
+	)
+
+	for _, child := range n.children {
+		area := child.box.area()
+		enlarge := child.box.union(box).area() - area
+
+		if enlarge < bestEnlarge || (enlarge == bestEnlarge && area < bestArea) {
+			best, bestEnlarge, bestArea = child, enlarge, area
+		}
+	}
+
+	return best
+}
+
+func (n *node[K]) size() int {
+	if n.leaf {
+		return len(n.items)
+	}
+
+	return len(n.children)
+}
+
+func (n *node[K]) updateBox() {
+	n.box = emptyRect()
+
+	for _, child := range n.children {
+		n.box = n.box.union(child.box)
+	}
+
+	for _, it := range n.items {
+		n.box = n.box.union(it.box)
+	}
+}
+
+func (n *node[K]) collect(items []*item[K]) []*item[K] {
+	items = append(items, n.items...)
+
+	for _, child := range n.children {
+		items = child.collect(items)
+	}
+
+	return items
+}
+
+// split moves half of entries into a new sibling node, entries are sorted along
+// the axis with the largest extent
+func (n *node[K]) split() *node[K] {
+	axis := 0
+	if n.box.maxY-n.box.minY > n.box.maxX-n.box.minX {
+		axis = 1
+	}
+
+	center := func(r rect) float64 {
+		if axis == 0 {
+			return r.minX + r.maxX
+		}
+
+		return r.minY + r.maxY
+	}
+
+	sibling := &node[K]{leaf: n.leaf}
+
+	if n.leaf {
+		slices.SortFunc(n.items, func(a, b *item[K]) int { return compareFloat(center(a.box), center(b.box)) })
+
+		half := len(n.items) / 2
+		sibling.items = slices.Clone(n.items[half:])
+		n.items = slices.Clip(n.items[:half])
+	} else {
+		slices.SortFunc(n.children, func(a, b *node[K]) int { return compareFloat(center(a.box), center(b.box)) })
+
+		half := len(n.children) / 2
+		sibling.children = slices.Clone(n.children[half:])
+		n.children = slices.Clip(n.children[:half])
+	}
+
+	n.updateBox()
+	sibling.updateBox()
+
+	return sibling
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// rect is a bounding box, empty when min > max
+type rect struct {
+	minX, minY, maxX, maxY float64
+}
+
+func emptyRect() rect {
+	return rect{minX: math.Inf(1), minY: math.Inf(1), maxX: math.Inf(-1), maxY: math.Inf(-1)}
+}
+
+func rectOf(box georm.Box2D) rect {
+	return rect{minX: box.MinX, minY: box.MinY, maxX: box.MaxX, maxY: box.MaxY}
+}
+
+func boundsOf(g georm.Geometric) rect {
+	if g == nil {
+		return emptyRect()
+	}
+
+	geomT := g.GeomT()
+	if geomT == nil {
+		return emptyRect()
+	}
+
+	b := geomT.Bounds()
+	if b.IsEmpty() || b.Layout() == geom.NoLayout {
+		return emptyRect()
+	}
+
+	return rect{minX: b.Min(0), minY: b.Min(1), maxX: b.Max(0), maxY: b.Max(1)}
+}
+
+func (r rect) empty() bool { return r.minX > r.maxX || r.minY > r.maxY }
+
+func (r rect) area() float64 {
+	if r.empty() {
+		return 0
+	}
+
+	return (r.maxX - r.minX) * (r.maxY - r.minY)
+}
+
+func (r rect) union(other rect) rect {
+	return rect{
+		minX: math.Min(r.minX, other.minX), minY: math.Min(r.minY, other.minY),
+		maxX: math.Max(r.maxX, other.maxX), maxY: math.Max(r.maxY, other.maxY),
+	}
+}
+
+func (r rect) intersects(other rect) bool {
+	return r.minX <= other.maxX && other.minX <= r.maxX && r.minY <= other.maxY && other.minY <= r.maxY
+}
+
+func (r rect) contains(other rect) bool {
+	return r.minX <= other.minX && r.minY <= other.minY && r.maxX >= other.maxX && r.maxY >= other.maxY
+}
+
+// distance returns distance from the point to the box, 0 inside the box
+func (r rect) distance(x, y float64) float64 {
+	if r.empty() {
+		return math.Inf(1)
+	}
+
+	dx := math.Max(0, math.Max(r.minX-x, x-r.maxX))
+	dy := math.Max(0, math.Max(r.minY-y, y-r.maxY))
+
+	return math.Hypot(dx, dy)
+}
+
+// nearestQueue is a priority queue of nodes and items ordered by distance
+type nearestQueue[K comparable] []nearestEntry[K]
+
+type nearestEntry[K comparable] struct {
+	node     *node[K]
+	item     *item[K]
+	distance float64
+
+	// exact is true when distance to the item geometry is calculated
+	exact bool
+}
+
+func (q nearestQueue[K]) Len() int { return len(q) }
+
+func (q nearestQueue[K]) Less(i, j int) bool {
+	if q[i].distance == q[j].distance {
+		// exact items first, so ties do not expand more nodes
+		return q[i].exact && !q[j].exact
+	}
+
+	return q[i].distance < q[j].distance
+}
+
+func (q nearestQueue[K]) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *nearestQueue[K]) Push(x any) { *q = append(*q, x.(nearestEntry[K])) }
+
+func (q *nearestQueue[K]) Pop() any {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return entry
+}
@@ -0,0 +1,216 @@
+package index
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/ybru-tech/georm"
+)
+
+type zone struct {
+	ID         uint `gorm:"primaryKey"`
+	Title      string
+	GeoPolygon georm.Polygon
+}
+
+func square(x, y, size float64) georm.Polygon {
+	return georm.NewPolygon([]geom.Coord{{x, y}, {x + size, y}, {x + size, y + size}, {x, y + size}, {x, y}})
+}
+
+func randomSquares(n int) map[int]georm.Geometric {
+	r := rand.New(rand.NewSource(42))
+
+	geometries := make(map[int]georm.Geometric, n)
+	for i := 0; i < n; i++ {
+		geometries[i] = square(r.Float64()*1000, r.Float64()*1000, 1+r.Float64()*20)
+	}
+
+	return geometries
+}
+
+// bruteSearch returns keys of geometries which bounding boxes intersect the box
+func bruteSearch(geometries map[int]georm.Geometric, box georm.Box2D) []int {
+	var keys []int
+
+	for key, g := range geometries {
+		b := georm.New(g.GeomT()).Bounds()
+		if b.MinX <= box.MaxX && box.MinX <= b.MaxX && b.MinY <= box.MaxY && box.MinY <= b.MaxY {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+func TestRTreeSearch(t *testing.T) {
+	geometries := randomSquares(1000)
+
+	built := Build(geometries)
+
+	inserted := New[int]()
+	for key, g := range geometries {
+		inserted.Insert(key, g)
+	}
+
+	require.Equal(t, len(geometries), built.Len())
+	require.Equal(t, len(geometries), inserted.Len())
+
+	for _, box := range []georm.Box2D{
+		{MinX: 0, MinY: 0, MaxX: 100, MaxY: 100},
+		{MinX: 500, MinY: 200, MaxX: 520, MaxY: 900},
+		{MinX: -10, MinY: -10, MaxX: -1, MaxY: -1},
+		{MinX: 0, MinY: 0, MaxX: 2000, MaxY: 2000},
+	} {
+		expect := bruteSearch(geometries, box)
+
+		assert.ElementsMatch(t, expect, built.Search(box))
+		assert.ElementsMatch(t, expect, inserted.Search(box))
+	}
+}
+
+func TestRTreeDelete(t *testing.T) {
+	geometries := randomSquares(500)
+	tree := Build(geometries)
+
+	for key := 0; key < 400; key++ {
+		require.True(t, tree.Delete(key))
+		delete(geometries, key)
+	}
+
+	assert.False(t, tree.Delete(0))
+	assert.Equal(t, len(geometries), tree.Len())
+
+	box := georm.Box2D{MinX: 0, MinY: 0, MaxX: 1000, MaxY: 1000}
+	assert.ElementsMatch(t, bruteSearch(geometries, box), tree.Search(box))
+
+	_, ok := tree.Get(0)
+	assert.False(t, ok)
+
+	g, ok := tree.Get(450)
+	require.True(t, ok)
+	assert.Equal(t, geometries[450], g)
+}
+
+func TestRTreeInsertReplaces(t *testing.T) {
+	tree := New[string]()
+
+	tree.Insert("zone", square(0, 0, 1))
+	tree.Insert("zone", square(100, 100, 1))
+
+	assert.Equal(t, 1, tree.Len())
+	assert.Empty(t, tree.Search(georm.Box2D{MinX: 0, MinY: 0, MaxX: 2, MaxY: 2}))
+	assert.Equal(t, []string{"zone"}, tree.Search(georm.Box2D{MinX: 100, MinY: 100, MaxX: 102, MaxY: 102}))
+
+	// empty geometry is stored, but not found by search
+	tree.Insert("empty", georm.Polygon{})
+	assert.Equal(t, 2, tree.Len())
+	assert.True(t, tree.Delete("empty"))
+}
+
+func TestRTreeQuery(t *testing.T) {
+	tree := Build(map[string]georm.Geometric{
+		"square":  square(0, 0, 10),
+		"inner":   square(2, 2, 2),
+		"outside": square(20, 20, 10),
+		"line":    georm.NewLineString(geom.Coord{0, 0}, geom.Coord{3, 3}),
+		"bbox":    georm.NewLineString(geom.Coord{2.5, 0}, geom.Coord{10, 2.5}),
+	})
+
+	point := georm.NewPoint(3, 3)
+
+	assert.ElementsMatch(t, []string{"square", "inner"}, tree.Query(point, georm.Contains))
+	assert.ElementsMatch(t, []string{"square", "inner", "line"}, tree.Query(point, georm.Intersects))
+	assert.ElementsMatch(t, []string{"line"}, tree.Query(point, georm.Touches))
+	assert.ElementsMatch(t, []string{"outside", "bbox"}, tree.Query(point, georm.Disjoint))
+}
+
+func TestRTreeNearest(t *testing.T) {
+	geometries := randomSquares(300)
+	tree := Build(geometries)
+
+	p := georm.NewPoint(500, 500)
+
+	keys := make([]int, 0, len(geometries))
+	for key := range geometries {
+		keys = append(keys, key)
+	}
+
+	distance := func(key int) float64 { return georm.New(geometries[key].GeomT()).Distance(p) }
+
+	slices.SortFunc(keys, func(a, b int) int { return int(math.Copysign(1, distance(a)-distance(b))) })
+
+	nearest := tree.Nearest(p, 5)
+	require.Len(t, nearest, 5)
+
+	for i, key := range nearest {
+		assert.InDelta(t, distance(keys[i]), distance(key), 1e-9)
+	}
+
+	assert.Len(t, tree.Nearest(p, 1000), len(geometries))
+	assert.Empty(t, tree.Nearest(p, 0))
+	assert.Empty(t, New[int]().Nearest(p, 1))
+}
+
+func TestRTreeConcurrentReaders(t *testing.T) {
+	tree := Build(randomSquares(200))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				tree.Search(georm.Box2D{MinX: 0, MinY: 0, MaxX: 500, MaxY: 500})
+				tree.Nearest(georm.NewPoint(float64(j), float64(i)), 3)
+
+				if i == 0 {
+					tree.Insert(1000+j, square(float64(j), 0, 1))
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 300, tree.Len())
+}
+
+func TestLoadExpectErrors(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+
+	tree, err := Load[uint, zone](db, "GeoPolygon")
+	require.NoError(t, err)
+	assert.Zero(t, tree.Len())
+
+	_, err = Load[uint, zone](db, "Unknown")
+	require.ErrorIs(t, err, ErrFieldNotFound)
+}
+
+func TestRTreeSearchAntimeridian(t *testing.T) {
+	tree := Build(map[string]georm.Geometric{
+		"east":  georm.NewPoint(179, 0),
+		"west":  georm.NewPoint(-179, 0),
+		"world": georm.NewLineString(geom.Coord{-180, 0}, geom.Coord{180, 0}),
+		"zero":  georm.NewPoint(0, 0),
+	})
+
+	assert.ElementsMatch(t, []string{"east", "west", "world"}, tree.Search(georm.Box2D{MinX: 170, MinY: -10, MaxX: -170, MaxY: 10}))
+}
@@ -0,0 +1,277 @@
+package georm
+
+import (
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Pair is a pair of models matched by SpatialJoin, Right is nil
+// for left models without matches in a left join
+type Pair[L, R any] struct {
+	Left  L
+	Right *R
+}
+
+// Join is a spatial join of two models built by SpatialJoin
+type Join[L, R any] struct {
+	db        *gorm.DB
+	predicate Predicate
+	leftJoin  bool
+	leftGeom  string
+	rightGeom string
+}
+
+// SpatialJoin joins models L and R matching the predicate between their
+// geometry fields, e.g. routes crossing zones:
+//
+//	pairs, err := georm.SpatialJoin(db.Where("routes.active"), &Route{}, &Zone{}, georm.Intersects).Find()
+//
+// Conditions, order and limit of db apply to the join query, the left table
+// has its own name there and the right table is joined under its name too,
+// or as "right_<table>" when both models share the table. Models of matching
+// pairs are loaded by primary keys, so hooks and scopes of models apply.
+// Models must have a single primary key and a single geometry field,
+// otherwise geometry fields are set by On
+func SpatialJoin[L, R any](db *gorm.DB, left *L, right *R, predicate Predicate) *Join[L, R] {
+	return &Join[L, R]{db: db, predicate: predicate}
+}
+
+// Left makes the join a left join, left models without matches are returned
+// in pairs with nil Right
+func (j *Join[L, R]) Left() *Join[L, R] {
+	joined := *j
+	joined.leftJoin = true
+
+	return &joined
+}
+
+// On sets geometry fields of the left and the right models by field or column names,
+// empty name keeps the only geometry field of the model
+func (j *Join[L, R]) On(leftGeom, rightGeom string) *Join[L, R] {
+	joined := *j
+	joined.leftGeom, joined.rightGeom = leftGeom, rightGeom
+
+	return &joined
+}
+
+// Find returns pairs of matching models in order of the join query
+func (j *Join[L, R]) Find() ([]Pair[L, R], error) {
+	left, err := newJoinSide(j.db, new(L), j.leftGeom)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := newJoinSide(j.db, new(R), j.rightGeom)
+	if err != nil {
+		return nil, err
+	}
+
+	if j.db.Statement.Table != "" {
+		left.table, left.alias = j.db.Statement.Table, j.db.Statement.Table
+	}
+
+	if right.alias == left.table {
+		right.alias = "right_" + right.table
+	}
+
+	keys, err := joinKeys(j.db.Model(new(L)), left, right, j.predicate, j.leftJoin)
+	if err != nil {
+		return nil, err
+	}
+
+	leftKeys, rightKeys := pairKeys(keys)
+
+	lefts, err := findByKeys[L](j.db, left, leftKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	rights, err := findByKeys[R](j.db, right, rightKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]Pair[L, R], 0, len(keys))
+
+	for _, pair := range keys {
+		l, ok := lefts[pair[0]]
+		if !ok {
+			continue
+		}
+
+		var r *R
+
+		if pair[1] != nil {
+			// models missing in the second query are excluded by their scopes
+			if r, ok = rights[pair[1]]; !ok {
+				continue
+			}
+		}
+
+		pairs = append(pairs, Pair[L, R]{Left: *l, Right: r})
+	}
+
+	return pairs, nil
+}
+
+// pairKeys returns distinct left and right keys of joined pairs in order
+// of the first pair, a model joined with many ones is loaded once
+func pairKeys(keys [][2]any) (left, right []any) {
+	leftSeen, rightSeen := map[any]bool{}, map[any]bool{}
+
+	for _, pair := range keys {
+		if !leftSeen[pair[0]] {
+			leftSeen[pair[0]] = true
+			left = append(left, pair[0])
+		}
+
+		if pair[1] != nil && !rightSeen[pair[1]] {
+			rightSeen[pair[1]] = true
+			right = append(right, pair[1])
+		}
+	}
+
+	return left, right
+}
+
+// findByKeys loads models by primary keys
+func findByKeys[T any](db *gorm.DB, side joinSide, keys []any) (map[any]*T, error) {
+	models := map[any]*T{}
+	if len(keys) == 0 {
+		return models, nil
+	}
+
+	var found []T
+
+	err := db.Session(&gorm.Session{NewDB: true}).
+		Where(clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: side.pk.DBName}, Values: keys}).
+		Find(&found).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range found {
+		key, _ := side.pk.ValueOf(db.Statement.Context, reflect.ValueOf(&found[i]).Elem())
+		models[key] = &found[i]
+	}
+
+	return models, nil
+}
+
+// joinSide is a model of a spatial join
+type joinSide struct {
+	schema    *schema.Schema
+	table     string
+	alias     string
+	pk        *schema.Field
+	geom      *schema.Field
+	deletedAt *schema.Field
+}
+
+var deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+
+func newJoinSide(db *gorm.DB, model any, geom string) (joinSide, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return joinSide{}, err
+	}
+
+	side, err := joinSideOf(stmt.Schema, geom)
+	if err != nil {
+		return side, fmt.Errorf("%w: %w", ErrInvalidRelation, err)
+	}
+
+	return side, nil
+}
+
+func joinSideOf(s *schema.Schema, geom string) (side joinSide, err error) {
+	side = joinSide{schema: s, table: s.Table, alias: s.Table, pk: s.PrioritizedPrimaryField}
+	if side.pk == nil {
+		return side, fmt.Errorf("single primary key is required in %s", s.Name)
+	}
+
+	if side.geom, err = relationGeom(s, geom); err != nil {
+		return side, err
+	}
+
+	for _, field := range s.Fields {
+		if field.DBName != "" && field.IndirectFieldType == deletedAtType {
+			side.deletedAt = field
+		}
+	}
+
+	return side, nil
+}
+
+// joinKeys returns primary keys of pairs of models matching the predicate
+// converted to the field types, the right key is nil for left models without
+// matches in a left join. The query of tx selects from the left table
+func joinKeys(tx *gorm.DB, left, right joinSide, predicate Predicate, leftJoin bool) ([][2]any, error) {
+	join := "JOIN"
+	if leftJoin {
+		join = "LEFT JOIN"
+	}
+
+	rightTable := clause.Table{Name: right.table}
+	if right.alias != right.table {
+		rightTable.Alias = right.alias
+	}
+
+	on := clause.Expr{
+		SQL: predicate.Name() + "(?, ?)",
+		Vars: []any{
+			clause.Column{Table: left.table, Name: left.geom.DBName},
+			clause.Column{Table: right.alias, Name: right.geom.DBName},
+		},
+	}
+
+	if right.deletedAt != nil {
+		on.SQL += " AND ? IS NULL"
+		on.Vars = append(on.Vars, clause.Column{Table: right.alias, Name: right.deletedAt.DBName})
+	}
+
+	var rows []map[string]interface{}
+
+	err := tx.
+		Select("?, ?",
+			clause.Column{Table: left.table, Name: left.pk.DBName, Alias: "georm_left_key"},
+			clause.Column{Table: right.alias, Name: right.pk.DBName, Alias: "georm_right_key"},
+		).
+		Joins(join+" ? ON ?", rightTable, on).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ctx      = tx.Statement.Context
+		leftKey  = reflect.New(left.schema.ModelType).Elem()
+		rightKey = reflect.New(right.schema.ModelType).Elem()
+		keys     = make([][2]any, 0, len(rows))
+	)
+
+	for _, row := range rows {
+		if err := left.pk.Set(ctx, leftKey, row["georm_left_key"]); err != nil {
+			return nil, err
+		}
+
+		pair := [2]any{}
+		pair[0], _ = left.pk.ValueOf(ctx, leftKey)
+
+		if value := row["georm_right_key"]; value != nil {
+			if err := right.pk.Set(ctx, rightKey, value); err != nil {
+				return nil, err
+			}
+
+			pair[1], _ = right.pk.ValueOf(ctx, rightKey)
+		}
+
+		keys = append(keys, pair)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,98 @@
+package georm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type testParcel struct {
+	ID        uint `gorm:"primaryKey"`
+	Area      Polygon
+	DeletedAt gorm.DeletedAt
+}
+
+func TestSpatialJoin(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Find   func(db *gorm.DB) error
+		Expect string
+	}{
+		{
+			Name: "join",
+			Find: func(db *gorm.DB) error {
+				_, err := SpatialJoin(db, &testHome{}, &testZone{}, Within).On("Location", "").Find()
+				return err
+			},
+			Expect: `SELECT "test_homes"."id" AS "georm_left_key", "test_zones"."id" AS "georm_right_key" FROM "test_homes"` +
+				` JOIN "test_zones" ON ST_Within("test_homes"."location", "test_zones"."area")`,
+		},
+		{
+			Name: "left join on fields",
+			Find: func(db *gorm.DB) error {
+				_, err := SpatialJoin(db.Where("test_routes.id > ?", 10).Order("test_routes.id").Limit(5), &testRoute{}, &testZone{}, Intersects).
+					Left().On("Path", "area").Find()
+				return err
+			},
+			Expect: `SELECT "test_routes"."id" AS "georm_left_key", "test_zones"."id" AS "georm_right_key" FROM "test_routes"` +
+				` LEFT JOIN "test_zones" ON ST_Intersects("test_routes"."path", "test_zones"."area")` +
+				` WHERE test_routes.id > 10 ORDER BY test_routes.id LIMIT 5`,
+		},
+		{
+			Name: "self join",
+			Find: func(db *gorm.DB) error {
+				_, err := SpatialJoin(db, &testZone{}, &testZone{}, Touches).Find()
+				return err
+			},
+			Expect: `FROM "test_zones" JOIN "test_zones" "right_test_zones"` +
+				` ON ST_Touches("test_zones"."area", "right_test_zones"."area")`,
+		},
+		{
+			Name: "soft delete",
+			Find: func(db *gorm.DB) error {
+				_, err := SpatialJoin(db, &testParcel{}, &testParcel{}, Intersects).Left().Find()
+				return err
+			},
+			Expect: `FROM "test_parcels" LEFT JOIN "test_parcels" "right_test_parcels"` +
+				` ON ST_Intersects("test_parcels"."area", "right_test_parcels"."area") AND "right_test_parcels"."deleted_at" IS NULL` +
+				` WHERE "test_parcels"."deleted_at" IS NULL`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			db, queries := relationDB(t)
+
+			require.NoError(t, test.Find(db))
+			assert.Contains(t, strings.Join(*queries, "\n"), test.Expect)
+		})
+	}
+}
+
+func TestSpatialJoinErrors(t *testing.T) {
+	db := dryRunDB(t)
+
+	_, err := SpatialJoin(db, &testRoute{}, &testZone{}, Intersects).Find()
+	require.ErrorIs(t, err, ErrInvalidRelation)
+	assert.ErrorContains(t, err, "several geometry fields in testRoute")
+
+	_, err = SpatialJoin(db, &testRoute{}, &testZone{}, Intersects).On("Path", "Route").Find()
+	require.ErrorIs(t, err, ErrInvalidRelation)
+	assert.ErrorContains(t, err, "geometry field Route not found in testZone")
+
+	// dry run finds no pairs
+	pairs, err := SpatialJoin(db, &testRoute{}, &testZone{}, Intersects).On("Path", "Area").Find()
+	require.NoError(t, err)
+	assert.Empty(t, pairs)
+}
+
+func TestPairKeys(t *testing.T) {
+	keys := [][2]any{{uint(1), uint(10)}, {uint(1), uint(11)}, {uint(2), uint(10)}, {uint(3), nil}, {uint(1), uint(12)}}
+
+	left, right := pairKeys(keys)
+	assert.Equal(t, []any{uint(1), uint(2), uint(3)}, left)
+	assert.Equal(t, []any{uint(10), uint(11), uint(12)}, right)
+}
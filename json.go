@@ -0,0 +1,117 @@
+package georm
+
+import (
+	"bytes"
+	"reflect"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/geojson"
+)
+
+// GeoJSONSRID is the SRID of GeoJSON coordinates (RFC 7946, WGS 84)
+const GeoJSONSRID = 4326
+
+var jsonNull = []byte("null")
+
+// MarshalJSON impl json.Marshaler, geometry is encoded as GeoJSON
+func (g Geometry[T]) MarshalJSON() ([]byte, error) {
+	if isNil(g.Geom) {
+		return jsonNull, nil
+	}
+
+	geometry := geom.T(g.Geom)
+
+	if SplitAntimeridianInGeoJSON && checkGeographic(geometry) == nil {
+		geometry = splitAntimeridian(geometry)
+	}
+
+	if OrientationInGeoJSON != OrientationAny {
+		geometry = orient(geometry, OrientationInGeoJSON == OrientationCCW)
+	}
+
+	return geojson.Marshal(geometry)
+}
+
+// UnmarshalJSON impl json.Unmarshaler, geometry is decoded from GeoJSON
+func (g *Geometry[T]) UnmarshalJSON(data []byte) error {
+	return g.UnmarshalGeoJSON(data, AxisXY)
+}
+
+// UnmarshalGeoJSON decodes geometry from GeoJSON with the given axis order
+// of coordinates, AxisYX swaps them into lon, lat
+func (g *Geometry[T]) UnmarshalGeoJSON(data []byte, order AxisOrder) error {
+	if bytes.Equal(bytes.TrimSpace(data), jsonNull) {
+		var zero T
+		g.Geom = zero
+
+		return nil
+	}
+
+	if err := checkBytes(len(data)); err != nil {
+		return err
+	}
+
+	var geometryT geom.T
+	if err := geojson.Unmarshal(data, &geometryT); err != nil {
+		return err
+	}
+
+	return g.decoded(setSRID(geometryT, GeoJSONSRID), order)
+}
+
+// decoded sets decoded geometry after axis order, size and coordinates checks
+func (g *Geometry[T]) decoded(geometryT geom.T, order AxisOrder) error {
+	if order == AxisYX {
+		geometryT = swapXY(geometryT)
+	}
+
+	geometry, ok := geometryT.(T)
+	if !ok {
+		return ErrUnexpectedValueType
+	}
+
+	if err := checkSize(geometry); err != nil {
+		return err
+	}
+
+	if err := checkCoords(geometry); err != nil {
+		return err
+	}
+
+	g.Geom = geometry
+
+	return nil
+}
+
+// isNil reports whether geometry is nil or a typed nil pointer
+func isNil(g geom.T) bool {
+	if g == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(g)
+
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// setSRID sets SRID of any geometry type
+func setSRID(g geom.T, srid int) geom.T {
+	switch g := g.(type) {
+	case *geom.Point:
+		return g.SetSRID(srid)
+	case *geom.LineString:
+		return g.SetSRID(srid)
+	case *geom.Polygon:
+		return g.SetSRID(srid)
+	case *geom.MultiPoint:
+		return g.SetSRID(srid)
+	case *geom.MultiLineString:
+		return g.SetSRID(srid)
+	case *geom.MultiPolygon:
+		return g.SetSRID(srid)
+	case *geom.GeometryCollection:
+		return g.SetSRID(srid)
+	default:
+		return g
+	}
+}
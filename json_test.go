@@ -0,0 +1,81 @@
+package georm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestGeometryMarshalJSON(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Input  any
+		Expect string
+	}{
+		{
+			Name:   "point",
+			Input:  New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{42, 42}).SetSRID(4326)),
+			Expect: `{"type":"Point","coordinates":[42,42]}`,
+		},
+		{
+			Name:   "polygon",
+			Input:  New(geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{1, 1}, {2, 1}, {2, 2}, {1, 1}}})),
+			Expect: `{"type":"Polygon","coordinates":[[[1,1],[2,1],[2,2],[1,1]]]}`,
+		},
+		{
+			Name:   "nil geometry",
+			Input:  Point{},
+			Expect: `null`,
+		},
+		{
+			Name:   "nil interface",
+			Input:  Geometry[geom.T]{},
+			Expect: `null`,
+		},
+		{
+			Name: "struct field",
+			Input: struct {
+				Point Point `json:"point"`
+			}{Point: New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 2}))},
+			Expect: `{"point":{"type":"Point","coordinates":[1,2]}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			actual, err := json.Marshal(test.Input)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, test.Expect, string(actual))
+		})
+	}
+}
+
+func TestGeometryUnmarshalJSON(t *testing.T) {
+	var point Point
+
+	err := json.Unmarshal([]byte(`{"type":"Point","coordinates":[42,42]}`), &point)
+	require.NoError(t, err)
+	assert.Equal(t, geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{42, 42}).SetSRID(4326), point.Geom)
+
+	var geometry Geometry[geom.T]
+
+	err = json.Unmarshal([]byte(`{"type":"LineString","coordinates":[[1,1],[2,2]]}`), &geometry)
+	require.NoError(t, err)
+	assert.Equal(t, geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{1, 1}, {2, 2}}).SetSRID(4326), geometry.Geom)
+
+	err = json.Unmarshal([]byte(`null`), &point)
+	require.NoError(t, err)
+	assert.Nil(t, point.Geom)
+
+	var polygon Polygon
+
+	err = json.Unmarshal([]byte(`{"type":"Point","coordinates":[42,42]}`), &polygon)
+	require.ErrorIs(t, err, ErrUnexpectedValueType)
+
+	err = json.Unmarshal([]byte(`{"type":"Unknown","coordinates":[42,42]}`), &polygon)
+	require.Error(t, err)
+}
@@ -0,0 +1,227 @@
+package georm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/twpayne/go-geom"
+)
+
+var ErrGeometryTooLarge = errors.New("geometry too large")
+
+// Geometry size limits enforced in Scan, Value and UnmarshalJSON, zero disables the limit
+var (
+	// MaxVertices limits total number of points of all parts
+	MaxVertices = 0
+
+	// MaxRings limits total number of polygon rings
+	MaxRings = 0
+
+	// MaxBytes limits size of encoded geometry: WKB in Scan and Value, GeoJSON in UnmarshalJSON
+	MaxBytes = 0
+
+	// MaxCollectionDepth limits nesting of geometry collections, top level collection has depth 1
+	MaxCollectionDepth = 0
+)
+
+// geometrySize describes size of geometry checked against limits
+type geometrySize struct {
+	vertices, rings, depth int
+}
+
+func (s geometrySize) check() error {
+	switch {
+	case MaxVertices > 0 && s.vertices > MaxVertices:
+		return fmt.Errorf("%w: %d vertices exceed limit %d", ErrGeometryTooLarge, s.vertices, MaxVertices)
+	case MaxRings > 0 && s.rings > MaxRings:
+		return fmt.Errorf("%w: %d rings exceed limit %d", ErrGeometryTooLarge, s.rings, MaxRings)
+	case MaxCollectionDepth > 0 && s.depth > MaxCollectionDepth:
+		return fmt.Errorf("%w: collection depth %d exceeds limit %d", ErrGeometryTooLarge, s.depth, MaxCollectionDepth)
+	default:
+		return nil
+	}
+}
+
+func checkBytes(n int) error {
+	if MaxBytes > 0 && n > MaxBytes {
+		return fmt.Errorf("%w: %d bytes exceed limit %d", ErrGeometryTooLarge, n, MaxBytes)
+	}
+
+	return nil
+}
+
+// checkSize checks decoded geometry against limits
+func checkSize(g geom.T) error {
+	if isNil(g) {
+		return nil
+	}
+
+	return sizeOf(g).check()
+}
+
+func sizeOf(g geom.T) geometrySize {
+	var size geometrySize
+
+	switch g := g.(type) {
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			childSize := sizeOf(child)
+
+			size.vertices += childSize.vertices
+			size.rings += childSize.rings
+			size.depth = max(size.depth, childSize.depth)
+		}
+
+		size.depth++
+
+		return size
+	case *geom.Polygon:
+		size.rings = g.NumLinearRings()
+	case *geom.MultiPolygon:
+		for _, ends := range g.Endss() {
+			size.rings += len(ends)
+		}
+	}
+
+	if stride := g.Stride(); stride > 0 {
+		size.vertices = len(g.FlatCoords()) / stride
+	}
+
+	return size
+}
+
+// EWKB geometry type flags
+const (
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+// checkWKB checks (E)WKB against limits walking its headers without decoding
+// coordinates, so huge geometries are rejected before memory is allocated.
+// Malformed input is left for the decoder to report
+func checkWKB(wkb []byte) error {
+	if err := checkBytes(len(wkb)); err != nil {
+		return err
+	}
+
+	if MaxVertices <= 0 && MaxRings <= 0 && MaxCollectionDepth <= 0 {
+		return nil
+	}
+
+	r := &wkbWalker{data: wkb}
+	r.walk(0)
+
+	return r.size.check()
+}
+
+// wkbWalker counts vertices, rings and collection depth of WKB,
+// stops when limits are exceeded or data is truncated
+type wkbWalker struct {
+	data  []byte
+	order binary.ByteOrder
+	size  geometrySize
+	done  bool
+}
+
+func (w *wkbWalker) uint32() uint32 {
+	if len(w.data) < 4 {
+		w.done = true
+		return 0
+	}
+
+	v := w.order.Uint32(w.data)
+	w.data = w.data[4:]
+
+	return v
+}
+
+func (w *wkbWalker) skip(n uint64) {
+	if uint64(len(w.data)) < n {
+		w.done = true
+		return
+	}
+
+	w.data = w.data[n:]
+}
+
+func (w *wkbWalker) points(n uint32, dims uint64) {
+	w.size.vertices += int(n)
+	if w.size.check() != nil {
+		w.done = true
+		return
+	}
+
+	w.skip(uint64(n) * dims * 8)
+}
+
+func (w *wkbWalker) walk(depth int) {
+	if w.done || len(w.data) < 1 {
+		w.done = true
+		return
+	}
+
+	if w.data[0] == 0 {
+		w.order = binary.BigEndian
+	} else {
+		w.order = binary.LittleEndian
+	}
+
+	w.data = w.data[1:]
+
+	t := w.uint32()
+	dims := uint64(2)
+
+	if t&ewkbZ != 0 {
+		dims++
+	}
+
+	if t&ewkbM != 0 {
+		dims++
+	}
+
+	if t&ewkbSRID != 0 {
+		w.skip(4)
+	}
+
+	// ISO WKB encodes dimensions as thousands of the type
+	base := t & 0xffff
+	switch base / 1000 {
+	case 1, 2:
+		dims++
+	case 3:
+		dims += 2
+	}
+
+	switch base % 1000 {
+	case 1: // Point
+		w.points(1, dims)
+	case 2: // LineString
+		w.points(w.uint32(), dims)
+	case 3: // Polygon
+		rings := w.uint32()
+
+		w.size.rings += int(rings)
+		for i := uint32(0); i < rings && !w.done; i++ {
+			w.points(w.uint32(), dims)
+		}
+	case 4, 5, 6, 7: // Multi* and GeometryCollection
+		if base%1000 == 7 {
+			depth++
+			w.size.depth = max(w.size.depth, depth)
+		}
+
+		n := w.uint32()
+		for i := uint32(0); i < n && !w.done; i++ {
+			if w.size.check() != nil {
+				w.done = true
+				return
+			}
+
+			w.walk(depth)
+		}
+	default:
+		w.done = true
+	}
+}
@@ -0,0 +1,119 @@
+package georm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/ewkb"
+)
+
+func setLimits(t *testing.T, vertices, rings, bytes, depth int) {
+	t.Helper()
+
+	MaxVertices, MaxRings, MaxBytes, MaxCollectionDepth = vertices, rings, bytes, depth
+	t.Cleanup(func() { MaxVertices, MaxRings, MaxBytes, MaxCollectionDepth = 0, 0, 0, 0 })
+}
+
+func encodeWKB(t *testing.T, g geom.T, byteOrder binary.ByteOrder) []byte {
+	t.Helper()
+
+	sb := &bytes.Buffer{}
+	require.NoError(t, ewkb.Write(sb, byteOrder, g))
+
+	return sb.Bytes()
+}
+
+var (
+	limitsPolygon = geom.NewPolygon(geom.XYZ).MustSetCoords([][]geom.Coord{
+		{{0, 0, 1}, {10, 0, 1}, {10, 10, 1}, {0, 10, 1}, {0, 0, 1}},
+		{{2, 2, 1}, {4, 2, 1}, {4, 4, 1}, {2, 2, 1}},
+	}).SetSRID(4326)
+
+	limitsCollection = geom.NewGeometryCollection().MustPush(
+		geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 1}),
+		geom.NewGeometryCollection().MustPush(
+			geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+				{{{5, 5}, {6, 5}, {6, 6}, {5, 5}}, {{5.2, 5.1}, {5.8, 5.1}, {5.8, 5.7}, {5.2, 5.1}}},
+			}),
+		),
+	)
+)
+
+func TestSizeOf(t *testing.T) {
+	assert.Equal(t, geometrySize{vertices: 9, rings: 2}, sizeOf(limitsPolygon))
+	assert.Equal(t, geometrySize{vertices: 13, rings: 3, depth: 2}, sizeOf(limitsCollection))
+}
+
+func TestCheckWKB(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Geom      geom.T
+		ByteOrder binary.ByteOrder
+		Limits    [4]int
+		Expect    bool
+	}{
+		{Name: "no limits", Geom: limitsPolygon, ByteOrder: binary.LittleEndian},
+		{Name: "within limits", Geom: limitsPolygon, ByteOrder: binary.LittleEndian, Limits: [4]int{9, 2, 1000, 1}},
+		{Name: "vertices", Geom: limitsPolygon, ByteOrder: binary.BigEndian, Limits: [4]int{8, 0, 0, 0}, Expect: true},
+		{Name: "rings", Geom: limitsPolygon, ByteOrder: binary.LittleEndian, Limits: [4]int{0, 1, 0, 0}, Expect: true},
+		{Name: "bytes", Geom: limitsPolygon, ByteOrder: binary.LittleEndian, Limits: [4]int{0, 0, 100, 0}, Expect: true},
+		{Name: "collection within limits", Geom: limitsCollection, ByteOrder: binary.LittleEndian, Limits: [4]int{13, 3, 0, 2}},
+		{Name: "collection vertices", Geom: limitsCollection, ByteOrder: binary.BigEndian, Limits: [4]int{12, 0, 0, 0}, Expect: true},
+		{Name: "collection rings", Geom: limitsCollection, ByteOrder: binary.LittleEndian, Limits: [4]int{0, 2, 0, 0}, Expect: true},
+		{Name: "collection depth", Geom: limitsCollection, ByteOrder: binary.LittleEndian, Limits: [4]int{0, 0, 0, 1}, Expect: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			wkb := encodeWKB(t, test.Geom, test.ByteOrder)
+
+			setLimits(t, test.Limits[0], test.Limits[1], test.Limits[2], test.Limits[3])
+
+			err := checkWKB(wkb)
+			if test.Expect {
+				require.ErrorIs(t, err, ErrGeometryTooLarge)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckWKBHugeCount(t *testing.T) {
+	// line string header claiming 2^32-1 points without coordinates
+	wkb := []byte{1, 2, 0, 0, 0, 0xff, 0xff, 0xff, 0xff}
+
+	setLimits(t, 1000, 0, 0, 0)
+	require.ErrorIs(t, checkWKB(wkb), ErrGeometryTooLarge)
+}
+
+func TestGeometryLimits(t *testing.T) {
+	setLimits(t, 4, 0, 0, 0)
+
+	line := New(geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}, {2, 2}, {3, 3}, {4, 4}}))
+
+	_, err := line.Value()
+	require.ErrorIs(t, err, ErrGeometryTooLarge)
+
+	var scanned LineString
+	require.ErrorIs(t, scanned.Scan(hex.EncodeToString(encodeWKB(t, line.Geom, binary.LittleEndian))), ErrGeometryTooLarge)
+	require.ErrorIs(t, scanned.Scan(encodeWKB(t, line.Geom, binary.LittleEndian)), ErrGeometryTooLarge)
+
+	err = json.Unmarshal([]byte(`{"type":"LineString","coordinates":[[0,0],[1,1],[2,2],[3,3],[4,4]]}`), &scanned)
+	require.ErrorIs(t, err, ErrGeometryTooLarge)
+
+	setLimits(t, 0, 0, 20, 0)
+
+	err = json.Unmarshal([]byte(`{"type":"Point","coordinates":[0,0]}`), &scanned)
+	require.ErrorIs(t, err, ErrGeometryTooLarge)
+
+	_, err = line.Value()
+	require.ErrorIs(t, err, ErrGeometryTooLarge)
+}
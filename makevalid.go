@@ -0,0 +1,413 @@
+package georm
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/xy"
+	"github.com/twpayne/go-geom/xy/location"
+)
+
+var ErrCannotMakeValid = errors.New("cannot make geometry valid")
+
+// MakeValidOnWrite enables MakeValid of geometries in Value,
+// geometries which cannot be repaired are rejected with ErrCannotMakeValid
+var MakeValidOnWrite = false
+
+// MakeValid repairs common defects of user drawn Polygon and MultiPolygon:
+// closes unclosed rings, removes repeated points and collapsed rings,
+// splits self-intersecting (bow-tie) rings into simple ones and orients
+// shells counter-clockwise and holes clockwise.
+//
+// A Polygon which splits into several parts can only be repaired into
+// Geometry[geom.T] or MultiPolygon. Other geometry types are returned as is.
+// ErrCannotMakeValid is returned when the repaired geometry is still invalid,
+// e.g. polygons of MultiPolygon overlap each other
+func (g Geometry[T]) MakeValid() (Geometry[T], error) {
+	if isNil(g.Geom) {
+		return g, nil
+	}
+
+	fixed, err := makeValid(g.Geom)
+	if err != nil {
+		return g, err
+	}
+
+	result, ok := fixed.(T)
+	if !ok {
+		return g, fmt.Errorf("%w: result is %T", ErrCannotMakeValid, fixed)
+	}
+
+	return Geometry[T]{result}, nil
+}
+
+func makeValid(g geom.T) (geom.T, error) {
+	var fixed geom.T
+
+	switch g := g.(type) {
+	case *geom.Polygon:
+		polygons := makeValidPolygon(g)
+
+		switch len(polygons) {
+		case 0:
+			fixed = geom.NewPolygon(g.Layout()).SetSRID(g.SRID())
+		case 1:
+			fixed = polygons[0]
+		default:
+			fixed = newMultiPolygon(g.Layout(), g.SRID(), polygons)
+		}
+	case *geom.MultiPolygon:
+		var polygons []*geom.Polygon
+
+		for i := 0; i < g.NumPolygons(); i++ {
+			polygons = append(polygons, makeValidPolygon(g.Polygon(i).SetSRID(g.SRID()))...)
+		}
+
+		fixed = newMultiPolygon(g.Layout(), g.SRID(), polygons)
+	default:
+		return g, nil
+	}
+
+	if err := validate(fixed); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCannotMakeValid, err)
+	}
+
+	return fixed, nil
+}
+
+func newMultiPolygon(layout geom.Layout, srid int, polygons []*geom.Polygon) *geom.MultiPolygon {
+	mp := geom.NewMultiPolygon(layout).SetSRID(srid)
+
+	for _, p := range polygons {
+		_ = mp.Push(p)
+	}
+
+	return mp
+}
+
+// makeValidPolygon repairs the polygon into zero or more simple polygons
+func makeValidPolygon(p *geom.Polygon) []*geom.Polygon {
+	var (
+		layout = p.Layout()
+		stride = p.Stride()
+
+		shells [][]float64
+		holes  [][]float64
+
+		// holes[:shellHoles] are loops of the shell
+		shellHoles int
+	)
+
+	for i := 0; i < p.NumLinearRings(); i++ {
+		loops := simpleLoops(p.LinearRing(i).FlatCoords(), stride)
+
+		if i == 0 {
+			// loops of the shell nested into other loops are holes (even-odd rule)
+			for j, loop := range loops {
+				depth := 0
+
+				for k, other := range loops {
+					if k != j && ringInside(layout, loop, other) {
+						depth++
+					}
+				}
+
+				if depth%2 == 0 {
+					shells = append(shells, loop)
+				} else {
+					holes = append(holes, loop)
+				}
+			}
+
+			shellHoles = len(holes)
+		} else {
+			holes = append(holes, loops...)
+		}
+	}
+
+	for i, shell := range shells {
+		shells[i] = orientRing(shell, stride, true)
+	}
+
+	for i, hole := range holes {
+		holes[i] = orientRing(hole, stride, false)
+	}
+
+	polygons := assemblePolygons(layout, p.SRID(), shells, holes)
+
+	// loops of the shell may share or overlap edges, holes may touch the shell
+	// at several points or cross it
+	if len(polygons) > 0 && validate(newMultiPolygon(layout, p.SRID(), polygons)) != nil {
+		polygons = dissolveLoops(layout, p.SRID(), slices.Concat(shells, holes[:shellHoles]), holes[shellHoles:])
+	}
+
+	return polygons
+}
+
+// dissolveLoops returns polygons of the area inside of an odd number of loops of
+// the shell and outside of holes: loops sharing edges are merged and shells
+// which holes touch at several points are split there. Vertices keep ordinates
+// beyond XY of the loop with the vertex, vertices of new intersections get zero
+func dissolveLoops(layout geom.Layout, srid int, shellLoops, holes [][]float64) []*geom.Polygon {
+	var (
+		stride = layout.Stride()
+		coords = map[[2]float64][]float64{}
+	)
+
+	operand := func(loops [][]float64) []*geom.Polygon {
+		polygons := make([]*geom.Polygon, 0, len(loops))
+
+		for _, loop := range loops {
+			polygons = append(polygons, geom.NewPolygonFlat(layout, loop, []int{len(loop)}))
+
+			for i := 0; i < len(loop); i += stride {
+				coords[[2]float64{loop[i], loop[i+1]}] = loop[i : i+stride]
+			}
+		}
+
+		return polygons
+	}
+
+	dissolved := overlayWindings(operand(shellLoops), operand(holes), func(winding [2]int) bool {
+		return winding[0]%2 != 0 && winding[1] == 0
+	})
+
+	for i, d := range dissolved {
+		var (
+			flatCoords = make([]float64, 0, len(d.FlatCoords())/2*stride)
+			ends       []int
+			start      int
+		)
+
+		for _, end := range d.Ends() {
+			for j := start; j < end; j += d.Stride() {
+				c, ok := coords[[2]float64{d.FlatCoords()[j], d.FlatCoords()[j+1]}]
+				if !ok {
+					c = append(slices.Clone(d.FlatCoords()[j:j+2]), make([]float64, stride-2)...)
+				}
+
+				flatCoords = append(flatCoords, c...)
+			}
+
+			start = end
+			ends = append(ends, len(flatCoords))
+		}
+
+		dissolved[i] = geom.NewPolygonFlat(layout, flatCoords, ends).SetSRID(srid)
+	}
+
+	return dissolved
+}
+
+// simpleLoops splits the ring into simple closed loops at self-intersections,
+// the ring is closed when unclosed, repeated points and collapsed loops are removed
+func simpleLoops(flatCoords []float64, stride int) [][]float64 {
+	ring := dedupeRing(flatCoords, stride)
+	if len(ring) < 4*stride {
+		return nil
+	}
+
+	ring = nodeRing(ring, stride)
+
+	type key [2]float64
+
+	var (
+		loops [][]float64
+		stack []float64
+		index = map[key]int{}
+	)
+
+	for i := 0; i < len(ring); i += stride {
+		c := ring[i : i+stride]
+		k := key{c[0], c[1]}
+
+		pos, visited := index[k]
+		if !visited {
+			index[k] = len(stack)
+			stack = append(stack, c...)
+
+			continue
+		}
+
+		// vertex repeats, cut the loop from it
+		loop := append(slices.Clone(stack[pos:]), c...)
+
+		for j := pos + stride; j < len(stack); j += stride {
+			delete(index, key{stack[j], stack[j+1]})
+		}
+
+		stack = stack[:pos+stride]
+
+		if len(loop) >= 4*stride && ringArea(loop, stride) != 0 {
+			loops = append(loops, loop)
+		}
+	}
+
+	return loops
+}
+
+// dedupeRing removes consecutive repeated points and closes the ring
+func dedupeRing(flatCoords []float64, stride int) []float64 {
+	ring := make([]float64, 0, len(flatCoords)+stride)
+
+	for i := 0; i < len(flatCoords); i += stride {
+		n := len(ring)
+		if n > 0 && ring[n-stride] == flatCoords[i] && ring[n-stride+1] == flatCoords[i+1] {
+			continue
+		}
+
+		ring = append(ring, flatCoords[i:i+stride]...)
+	}
+
+	if n := len(ring); n > 0 && (ring[0] != ring[n-stride] || ring[1] != ring[n-stride+1]) {
+		ring = append(ring, ring[:stride]...)
+	}
+
+	return ring
+}
+
+// nodeRing inserts self-intersection points of the ring as vertices
+func nodeRing(ring []float64, stride int) []float64 {
+	segments := ringSegments(ring, stride)
+
+	nodes := make([][]geom.Coord, len(segments))
+
+	addNode := func(i int, c geom.Coord) {
+		s := segments[i]
+		if c.Equal(geom.XY, s.start) || c.Equal(geom.XY, s.end) {
+			return
+		}
+
+		nodes[i] = append(nodes[i], c)
+	}
+
+	segmentPairs(segments, nil, func(i, j int) bool {
+		r := intersect(segments[i], segments[j])
+
+		switch r.kind {
+		case pointIntersection:
+			addNode(i, r.at)
+			addNode(j, r.at)
+		case collinearIntersection:
+			for _, c := range []geom.Coord{segments[i].start, segments[i].end} {
+				if onSegment(segments[j], c) {
+					addNode(j, c)
+				}
+			}
+
+			for _, c := range []geom.Coord{segments[j].start, segments[j].end} {
+				if onSegment(segments[i], c) {
+					addNode(i, c)
+				}
+			}
+		}
+
+		return true
+	})
+
+	noded := make([]float64, 0, len(ring))
+
+	// ring has no repeated points, so segment i starts at vertex i
+	for i, s := range segments {
+		start, end := ring[i*stride:(i+1)*stride], ring[(i+1)*stride:(i+2)*stride]
+		noded = append(noded, start...)
+
+		slices.SortFunc(nodes[i], func(c1, c2 geom.Coord) int {
+			return cmp.Compare(distance2(s.start, c1), distance2(s.start, c2))
+		})
+
+		for _, c := range nodes[i] {
+			n := len(noded)
+			if noded[n-stride] == c[0] && noded[n-stride+1] == c[1] {
+				continue
+			}
+
+			noded = append(noded, interpolate(start, end, c)...)
+		}
+	}
+
+	return append(noded, ring[:stride]...)
+}
+
+// interpolate returns coordinate at xy of the segment with extra ordinates
+// interpolated between segment ends
+func interpolate(start, end []float64, at geom.Coord) []float64 {
+	c := slices.Clone(start)
+	c[0], c[1] = at[0], at[1]
+
+	length := distance2(start, end)
+	if length == 0 || len(c) == 2 {
+		return c
+	}
+
+	t := distance2(start, at) / length
+
+	for i := 2; i < len(c); i++ {
+		c[i] = start[i] + (end[i]-start[i])*math.Sqrt(t)
+	}
+
+	return c
+}
+
+func distance2(c1, c2 []float64) float64 {
+	dx, dy := c2[0]-c1[0], c2[1]-c1[1]
+	return dx*dx + dy*dy
+}
+
+// ringArea returns signed area of the closed ring, positive for counter-clockwise rings
+func ringArea(ring []float64, stride int) float64 {
+	var area float64
+
+	for i := stride; i < len(ring); i += stride {
+		area += ring[i-stride]*ring[i+1] - ring[i]*ring[i-stride+1]
+	}
+
+	return area / 2
+}
+
+// orientRing returns the ring oriented counter-clockwise or clockwise
+func orientRing(ring []float64, stride int, ccw bool) []float64 {
+	if (ringArea(ring, stride) > 0) == ccw {
+		return ring
+	}
+
+	reversed := make([]float64, 0, len(ring))
+	for i := len(ring) - stride; i >= 0; i -= stride {
+		reversed = append(reversed, ring[i:i+stride]...)
+	}
+
+	return reversed
+}
+
+// ringInside reports whether simple ring lies inside the other one,
+// rings must not cross each other
+func ringInside(layout geom.Layout, ring, other []float64) bool {
+	_, loc := locateRing(layout, ring, other)
+	if loc == location.Boundary {
+		// all vertices on the boundary, check a middle of the first segment
+		stride := layout.Stride()
+		middle := geom.Coord{(ring[0] + ring[stride]) / 2, (ring[1] + ring[stride+1]) / 2}
+
+		return xy.LocatePointInRing(geom.XY, middle, xyCoords(other, stride)) == location.Interior
+	}
+
+	return loc == location.Interior
+}
+
+// xyCoords returns xy part of flat coordinates
+func xyCoords(flatCoords []float64, stride int) []float64 {
+	if stride == 2 {
+		return flatCoords
+	}
+
+	result := make([]float64, 0, len(flatCoords)/stride*2)
+	for i := 0; i < len(flatCoords); i += stride {
+		result = append(result, flatCoords[i], flatCoords[i+1])
+	}
+
+	return result
+}
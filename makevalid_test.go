@@ -0,0 +1,167 @@
+package georm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestGeometryMakeValid(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Geom   geom.T
+		Expect geom.T
+	}{
+		{
+			Name:   "nil",
+			Geom:   nil,
+			Expect: nil,
+		},
+		{
+			Name:   "point is returned as is",
+			Geom:   geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{42, 42}),
+			Expect: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{42, 42}),
+		},
+		{
+			Name:   "valid polygon",
+			Geom:   geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}),
+			Expect: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}),
+		},
+		{
+			Name:   "unclosed ring",
+			Geom:   geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}),
+			Expect: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}),
+		},
+		{
+			Name:   "repeated points",
+			Geom:   geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {0, 0}, {1, 0}, {1, 1}, {1, 1}, {0, 1}, {0, 0}}}),
+			Expect: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}),
+		},
+		{
+			Name: "orientation",
+			Geom: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}},
+				{{2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}},
+			}),
+			Expect: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+				{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}},
+			}),
+		},
+		{
+			Name: "bow-tie",
+			Geom: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {2, 2}, {2, 0}, {0, 2}, {0, 0}}}),
+			Expect: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{1, 1}, {2, 0}, {2, 2}, {1, 1}}},
+				{{{0, 0}, {1, 1}, {0, 2}, {0, 0}}},
+			}),
+		},
+		{
+			Name:   "collapsed spike",
+			Geom:   geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {1, 2}, {1, 1}, {0, 1}, {0, 0}}}),
+			Expect: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}),
+		},
+		{
+			Name:   "collapsed ring",
+			Geom:   geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 1}, {2, 2}, {0, 0}}}),
+			Expect: geom.NewPolygon(geom.XY),
+		},
+		{
+			Name: "hole outside shell is dropped",
+			Geom: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+				{{20, 20}, {21, 20}, {21, 21}, {20, 20}},
+			}),
+			Expect: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}),
+		},
+		{
+			Name: "multi polygon",
+			Geom: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {2, 2}, {2, 0}, {0, 2}}},
+				{{{5, 5}, {6, 5}, {6, 6}, {5, 6}, {5, 5}}},
+			}),
+			Expect: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{1, 1}, {2, 0}, {2, 2}, {1, 1}}},
+				{{{0, 0}, {1, 1}, {0, 2}, {0, 0}}},
+				{{{5, 5}, {6, 5}, {6, 6}, {5, 6}, {5, 5}}},
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			g, err := New(test.Geom).MakeValid()
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expect, g.Geom)
+			assert.True(t, g.IsValid())
+		})
+	}
+}
+
+func TestGeometryMakeValidTopology(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Geom  geom.T
+		Parts int
+	}{
+		{
+			Name:  "hole touching shell at two points",
+			Geom:  geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 2}, {4, 4}, {4, 5}, {3, 0}, {2, 1}, {5, 4}, {3, 0}, {0, 2}}}),
+			Parts: 3,
+		},
+		{
+			Name:  "loops sharing edge",
+			Geom:  geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 5}, {1, 4}, {0, 0}, {0, 1}, {2, 0}, {0, 1}}}),
+			Parts: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			g, err := New(test.Geom).MakeValid()
+			require.NoError(t, err)
+
+			assert.True(t, g.IsValid())
+
+			parts := 1
+			if mp, ok := g.Geom.(*geom.MultiPolygon); ok {
+				parts = mp.NumPolygons()
+			}
+
+			assert.Equal(t, test.Parts, parts)
+		})
+	}
+}
+
+func TestGeometryMakeValidErrors(t *testing.T) {
+	bowTie := New(geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {2, 2}, {2, 0}, {0, 2}, {0, 0}}}))
+
+	_, err := bowTie.MakeValid()
+	require.ErrorIs(t, err, ErrCannotMakeValid)
+
+	overlapping := New(geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+		{{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}},
+		{{{1, 1}, {3, 1}, {3, 3}, {1, 3}, {1, 1}}},
+	}))
+
+	_, err = overlapping.MakeValid()
+	require.ErrorIs(t, err, ErrCannotMakeValid)
+	require.ErrorIs(t, err, ErrInvalidGeometry)
+}
+
+func TestGeometryValueMakeValidOnWrite(t *testing.T) {
+	unclosed := New(geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}))
+
+	MakeValidOnWrite, ValidateOnWrite = true, true
+	defer func() { MakeValidOnWrite, ValidateOnWrite = false, false }()
+
+	value, err := unclosed.Value()
+	require.NoError(t, err)
+
+	var scanned Polygon
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, scanned.IsValid())
+}
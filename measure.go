@@ -0,0 +1,306 @@
+package georm
+
+import (
+	"math"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/xy"
+	"github.com/twpayne/go-geom/xy/location"
+)
+
+// Planar measures are calculated in units of geometry coordinates,
+// see geodesic.go for measures in meters of lon/lat geometries
+
+// Area returns planar area of polygons, 0 for other geometries
+func (g Geometry[T]) Area() float64 {
+	if isNil(g.Geom) {
+		return 0
+	}
+
+	return measure(g.Geom, func(g geom.T) float64 {
+		p, ok := g.(*geom.Polygon)
+		if !ok {
+			return 0
+		}
+
+		return polygonArea(p, func(ring []float64) float64 { return math.Abs(ringArea(ring, p.Stride())) })
+	})
+}
+
+// Length returns planar length of lines, 0 for other geometries as in PostGIS ST_Length
+func (g Geometry[T]) Length() float64 {
+	if isNil(g.Geom) {
+		return 0
+	}
+
+	return measure(g.Geom, func(g geom.T) float64 {
+		l, ok := g.(*geom.LineString)
+		if !ok {
+			return 0
+		}
+
+		return lineLength(l.FlatCoords(), l.Stride(), planarDistance)
+	})
+}
+
+// Perimeter returns planar length of polygon rings, 0 for other geometries
+func (g Geometry[T]) Perimeter() float64 {
+	if isNil(g.Geom) {
+		return 0
+	}
+
+	return measure(g.Geom, func(g geom.T) float64 {
+		p, ok := g.(*geom.Polygon)
+		if !ok {
+			return 0
+		}
+
+		return polygonPerimeter(p, planarDistance)
+	})
+}
+
+// Centroid returns planar center of mass of the highest dimension parts of geometry:
+// area weighted for polygons, length weighted for lines and mean of points.
+// Empty point is returned for empty geometry
+func (g Geometry[T]) Centroid() Point {
+	var sum centroidSum
+
+	if !isNil(g.Geom) {
+		eachPart(g.Geom, sum.add)
+	}
+
+	centroid := geom.NewPointEmpty(geom.XY)
+	if sum.weight != 0 {
+		centroid.MustSetCoords(geom.Coord{sum.x / sum.weight, sum.y / sum.weight})
+	}
+
+	if !isNil(g.Geom) {
+		centroid.SetSRID(g.Geom.SRID())
+	}
+
+	return New(centroid)
+}
+
+// Distance returns planar distance from the point to the closest point of geometry,
+// 0 when the point lies inside a polygon, NaN when either geometry is empty
+func (g Geometry[T]) Distance(p Point) float64 {
+	if isNil(g.Geom) || isNil(p.Geom) || p.Geom.Empty() {
+		return math.NaN()
+	}
+
+	return pointDistance(g.Geom, p.Geom.Coords(), planarDistance, planarSegmentDistance)
+}
+
+func planarDistance(c1, c2 []float64) float64 {
+	return math.Sqrt(distance2(c1, c2))
+}
+
+func planarSegmentDistance(p, start, end []float64) float64 {
+	return xy.DistanceFromPointToLine(p, start, end)
+}
+
+// eachPart calls fn for simple geometries of multi geometries and collections
+func eachPart(g geom.T, fn func(geom.T)) {
+	switch g := g.(type) {
+	case *geom.MultiPoint:
+		for i := 0; i < g.NumPoints(); i++ {
+			fn(g.Point(i))
+		}
+	case *geom.MultiLineString:
+		for i := 0; i < g.NumLineStrings(); i++ {
+			fn(g.LineString(i))
+		}
+	case *geom.MultiPolygon:
+		for i := 0; i < g.NumPolygons(); i++ {
+			fn(g.Polygon(i))
+		}
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			eachPart(child, fn)
+		}
+	default:
+		fn(g)
+	}
+}
+
+// measure sums fn over simple parts of geometry
+func measure(g geom.T, fn func(geom.T) float64) float64 {
+	var total float64
+
+	eachPart(g, func(part geom.T) { total += fn(part) })
+
+	return total
+}
+
+// polygonArea returns area of the shell minus areas of holes
+func polygonArea(p *geom.Polygon, ringArea func(ring []float64) float64) float64 {
+	var area float64
+
+	for i := 0; i < p.NumLinearRings(); i++ {
+		if a := ringArea(p.LinearRing(i).FlatCoords()); i == 0 {
+			area += a
+		} else {
+			area -= a
+		}
+	}
+
+	return area
+}
+
+func polygonPerimeter(p *geom.Polygon, distance func(c1, c2 []float64) float64) float64 {
+	var perimeter float64
+
+	for i := 0; i < p.NumLinearRings(); i++ {
+		perimeter += lineLength(p.LinearRing(i).FlatCoords(), p.Stride(), distance)
+	}
+
+	return perimeter
+}
+
+func lineLength(flatCoords []float64, stride int, distance func(c1, c2 []float64) float64) float64 {
+	var length float64
+
+	for i := stride; i < len(flatCoords); i += stride {
+		length += distance(flatCoords[i-stride:i], flatCoords[i:i+stride])
+	}
+
+	return length
+}
+
+// centroidSum accumulates weighted centers of the highest dimension parts
+type centroidSum struct {
+	dimension    int
+	x, y, weight float64
+}
+
+func (s *centroidSum) push(dimension int, x, y, weight float64) {
+	if dimension < s.dimension || weight == 0 {
+		return
+	}
+
+	if dimension > s.dimension {
+		*s = centroidSum{dimension: dimension}
+	}
+
+	s.x += x * weight
+	s.y += y * weight
+	s.weight += weight
+}
+
+func (s *centroidSum) add(g geom.T) {
+	flatCoords, stride := g.FlatCoords(), g.Stride()
+	if len(flatCoords) == 0 {
+		return
+	}
+
+	switch g := g.(type) {
+	case *geom.Point:
+		s.push(0, flatCoords[0], flatCoords[1], 1)
+	case *geom.LineString:
+		for i := stride; i < len(flatCoords); i += stride {
+			start, end := flatCoords[i-stride:i], flatCoords[i:i+stride]
+			s.push(1, (start[0]+end[0])/2, (start[1]+end[1])/2, planarDistance(start, end))
+		}
+	case *geom.Polygon:
+		for i := 0; i < g.NumLinearRings(); i++ {
+			x, y, area := ringCentroid(g.LinearRing(i).FlatCoords(), stride)
+			if i > 0 {
+				area = -area
+			}
+
+			s.push(2, x, y, area)
+		}
+	}
+}
+
+// ringCentroid returns centroid and absolute area of the ring, coordinates are
+// taken relative to the first point to reduce rounding errors
+func ringCentroid(ring []float64, stride int) (x, y, area float64) {
+	var (
+		x0, y0     = ring[0], ring[1]
+		cx, cy, a2 float64
+	)
+
+	for i := stride; i < len(ring); i += stride {
+		x1, y1 := ring[i-stride]-x0, ring[i-stride+1]-y0
+		x2, y2 := ring[i]-x0, ring[i+1]-y0
+
+		cross := x1*y2 - x2*y1
+		cx += (x1 + x2) * cross
+		cy += (y1 + y2) * cross
+		a2 += cross
+	}
+
+	if a2 == 0 {
+		return 0, 0, 0
+	}
+
+	return x0 + cx/(3*a2), y0 + cy/(3*a2), math.Abs(a2) / 2
+}
+
+// pointDistance returns the smallest distance from the point to parts of geometry
+func pointDistance(
+	g geom.T, p geom.Coord,
+	distance func(c1, c2 []float64) float64,
+	segmentDistance func(p, start, end []float64) float64,
+) float64 {
+	result := math.NaN()
+
+	eachPart(g, func(part geom.T) {
+		flatCoords, stride := part.FlatCoords(), part.Stride()
+		if len(flatCoords) == 0 {
+			return
+		}
+
+		d := math.Inf(1)
+
+		switch part := part.(type) {
+		case *geom.Point:
+			d = distance(p, flatCoords)
+		case *geom.LineString:
+			d = linesDistance(p, flatCoords, []int{len(flatCoords)}, stride, distance, segmentDistance)
+		case *geom.Polygon:
+			if pointInPolygon(part, p) {
+				d = 0
+			} else {
+				d = linesDistance(p, flatCoords, part.Ends(), stride, distance, segmentDistance)
+			}
+		}
+
+		if math.IsNaN(result) || d < result {
+			result = d
+		}
+	})
+
+	return result
+}
+
+func linesDistance(
+	p geom.Coord, flatCoords []float64, ends []int, stride int,
+	distance func(c1, c2 []float64) float64,
+	segmentDistance func(p, start, end []float64) float64,
+) float64 {
+	var (
+		result = math.Inf(1)
+		offset int
+	)
+
+	for _, end := range ends {
+		if end-offset == stride {
+			result = math.Min(result, distance(p, flatCoords[offset:end]))
+		}
+
+		for i := offset + stride; i < end; i += stride {
+			result = math.Min(result, segmentDistance(p, flatCoords[i-stride:i], flatCoords[i:i+stride]))
+		}
+
+		offset = end
+	}
+
+	return result
+}
+
+// pointInPolygon reports whether the point lies inside the polygon or on its boundary
+func pointInPolygon(p *geom.Polygon, c geom.Coord) bool {
+	return locatePointInPolygon(p, c) != location.Exterior
+}
@@ -0,0 +1,120 @@
+package georm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twpayne/go-geom"
+)
+
+var (
+	measurePolygon = NewPolygon(
+		[]geom.Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		[]geom.Coord{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}},
+	)
+	measureLine = NewLineString(geom.Coord{0, 0}, geom.Coord{3, 4}, geom.Coord{3, 10})
+)
+
+func TestGeometryPlanarMeasures(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Geom          geom.T
+		Area          float64
+		Length        float64
+		Perimeter     float64
+		Centroid      geom.Coord
+		EmptyCentroid bool
+	}{
+		{
+			Name:          "nil",
+			Geom:          nil,
+			EmptyCentroid: true,
+		},
+		{
+			Name:     "point",
+			Geom:     NewPoint(1, 2).Geom,
+			Centroid: geom.Coord{1, 2},
+		},
+		{
+			Name:     "line string",
+			Geom:     measureLine.Geom,
+			Length:   11,
+			Centroid: geom.Coord{(1.5*5 + 3*6) / 11, (2*5 + 7*6) / 11.0},
+		},
+		{
+			Name:      "polygon with hole",
+			Geom:      measurePolygon.Geom,
+			Area:      96,
+			Perimeter: 48,
+			Centroid:  geom.Coord{(5*100 - 3*4) / 96.0, (5*100 - 3*4) / 96.0},
+		},
+		{
+			Name: "multi polygon",
+			Geom: NewMultiPolygon(
+				[][]geom.Coord{{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}},
+				[][]geom.Coord{{{10, 0}, {12, 0}, {12, 2}, {10, 2}, {10, 0}}},
+			).Geom,
+			Area:      8,
+			Perimeter: 16,
+			Centroid:  geom.Coord{6, 1},
+		},
+		{
+			Name: "collection uses highest dimension",
+			Geom: geom.NewGeometryCollection().MustPush(
+				geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{100, 100}),
+				geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {0, 2}}),
+			),
+			Length:   2,
+			Centroid: geom.Coord{0, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			g := New(test.Geom)
+
+			assert.InDelta(t, test.Area, g.Area(), 1e-9)
+			assert.InDelta(t, test.Length, g.Length(), 1e-9)
+			assert.InDelta(t, test.Perimeter, g.Perimeter(), 1e-9)
+
+			centroid := g.Centroid()
+			if test.EmptyCentroid {
+				assert.True(t, centroid.Geom.Empty())
+				return
+			}
+
+			assert.InDelta(t, test.Centroid[0], centroid.X(), 1e-9)
+			assert.InDelta(t, test.Centroid[1], centroid.Y(), 1e-9)
+		})
+	}
+}
+
+func TestGeometryDistance(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Geom   geom.T
+		Point  Point
+		Expect float64
+	}{
+		{Name: "point", Geom: NewPoint(0, 0).Geom, Point: NewPoint(3, 4), Expect: 5},
+		{Name: "line string", Geom: measureLine.Geom, Point: NewPoint(5, 8), Expect: 2},
+		{Name: "inside polygon", Geom: measurePolygon.Geom, Point: NewPoint(8, 8), Expect: 0},
+		{Name: "inside hole", Geom: measurePolygon.Geom, Point: NewPoint(3, 3.5), Expect: 0.5},
+		{Name: "outside polygon", Geom: measurePolygon.Geom, Point: NewPoint(13, 14), Expect: 5},
+		{Name: "empty geometry", Geom: geom.NewLineString(geom.XY), Point: NewPoint(0, 0), Expect: math.NaN()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			d := New(test.Geom).Distance(test.Point)
+
+			if math.IsNaN(test.Expect) {
+				assert.True(t, math.IsNaN(d))
+				return
+			}
+
+			assert.InDelta(t, test.Expect, d, 1e-9)
+		})
+	}
+}
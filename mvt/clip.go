@@ -0,0 +1,170 @@
+package mvt
+
+// box is a square clipping area in tile screen space
+type box struct {
+	min, max float64
+}
+
+func (b box) contains(p point) bool {
+	return p[0] >= b.min && p[0] <= b.max && p[1] >= b.min && p[1] <= b.max
+}
+
+// clipLine splits the line into parts lying inside the box
+func (b box) clipLine(line path) []path {
+	var (
+		parts   []path
+		current path
+	)
+
+	flush := func() {
+		if len(current) > 1 {
+			parts = append(parts, current)
+		}
+
+		current = nil
+	}
+
+	for i := 1; i < len(line); i++ {
+		start, end, ok := b.clipSegment(line[i-1], line[i])
+		if !ok {
+			flush()
+			continue
+		}
+
+		if len(current) == 0 || current[len(current)-1] != start {
+			flush()
+			current = path{start}
+		}
+
+		current = append(current, end)
+
+		// segment leaves the box
+		if end != line[i] {
+			flush()
+		}
+	}
+
+	flush()
+
+	return parts
+}
+
+// clipSegment clips the segment using the Liang–Barsky algorithm
+func (b box) clipSegment(start, end point) (point, point, bool) {
+	var (
+		t0, t1 = 0.0, 1.0
+		dx, dy = end[0] - start[0], end[1] - start[1]
+	)
+
+	edges := [4][2]float64{
+		{-dx, start[0] - b.min},
+		{dx, b.max - start[0]},
+		{-dy, start[1] - b.min},
+		{dy, b.max - start[1]},
+	}
+
+	for _, edge := range edges {
+		p, q := edge[0], edge[1]
+
+		if p == 0 {
+			if q < 0 {
+				return start, end, false
+			}
+
+			continue
+		}
+
+		r := q / p
+
+		if p < 0 {
+			if r > t1 {
+				return start, end, false
+			}
+
+			t0 = max(t0, r)
+		} else {
+			if r < t0 {
+				return start, end, false
+			}
+
+			t1 = min(t1, r)
+		}
+	}
+
+	clippedStart, clippedEnd := start, end
+
+	if t0 > 0 {
+		clippedStart = point{start[0] + t0*dx, start[1] + t0*dy}
+	}
+
+	if t1 < 1 {
+		clippedEnd = point{start[0] + t1*dx, start[1] + t1*dy}
+	}
+
+	return clippedStart, clippedEnd, true
+}
+
+// clipRing clips the ring using the Sutherland–Hodgman algorithm,
+// the result is an open ring, i.e. the first point is not repeated
+func (b box) clipRing(ring path) path {
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+
+	for edge := 0; edge < 4 && len(ring) > 0; edge++ {
+		var (
+			clipped path
+			prev    = ring[len(ring)-1]
+		)
+
+		for _, cur := range ring {
+			curInside, prevInside := b.inside(cur, edge), b.inside(prev, edge)
+
+			if curInside != prevInside {
+				clipped = append(clipped, b.intersect(prev, cur, edge))
+			}
+
+			if curInside {
+				clipped = append(clipped, cur)
+			}
+
+			prev = cur
+		}
+
+		ring = clipped
+	}
+
+	return ring
+}
+
+func (b box) inside(p point, edge int) bool {
+	switch edge {
+	case 0:
+		return p[0] >= b.min
+	case 1:
+		return p[0] <= b.max
+	case 2:
+		return p[1] >= b.min
+	default:
+		return p[1] <= b.max
+	}
+}
+
+// intersect returns intersection of the segment with the box edge line
+func (b box) intersect(start, end point, edge int) point {
+	var (
+		axis  = edge / 2
+		bound = b.min
+	)
+
+	if edge%2 == 1 {
+		bound = b.max
+	}
+
+	t := (bound - start[axis]) / (end[axis] - start[axis])
+
+	p := point{start[0] + t*(end[0]-start[0]), start[1] + t*(end[1]-start[1])}
+	p[axis] = bound
+
+	return p
+}
@@ -0,0 +1,582 @@
+package mvt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+
+	"github.com/ybru-tech/georm"
+)
+
+var (
+	ErrUnsupportedGeometry = errors.New("unsupported geometry type")
+	ErrUnsupportedSRID     = errors.New("unsupported geometry srid")
+	ErrUnsupportedValue    = errors.New("unsupported property value type")
+)
+
+// geometry types of vector_tile.proto
+const (
+	typePoint      = 1
+	typeLineString = 2
+	typePolygon    = 3
+)
+
+// geometry commands of vector_tile.proto
+const (
+	cmdMoveTo    = 1
+	cmdLineTo    = 2
+	cmdClosePath = 7
+)
+
+const (
+	earthRadius = 6378137
+	maxLatitude = 85.0511287798066
+)
+
+// Feature is a geometry with properties encoded into a tile layer
+type Feature struct {
+	// ID of the feature, zero value is not encoded
+	ID uint64
+
+	// Geometry in EPSG:4326 or EPSG:3857, SRID 0 is treated as georm.SRID
+	Geometry geom.T
+
+	// Properties of the feature, values must be strings, numbers or booleans
+	Properties map[string]any
+}
+
+// NewFeature creates a feature from a georm geometry
+func NewFeature[T geom.T](id uint64, geometry georm.Geometry[T], properties map[string]any) Feature {
+	return Feature{ID: id, Geometry: geometry.Geom, Properties: properties}
+}
+
+// FeatureLayer is a named set of features encoded into a tile
+type FeatureLayer struct {
+	Name string
+
+	// Extent is the tile size in screen units, DefaultExtent by default
+	Extent int
+
+	// Buffer is the clipping buffer in screen units, DefaultBuffer by default
+	Buffer int
+
+	Features []Feature
+}
+
+// Encode renders layers of in-memory features into Mapbox Vector Tile bytes.
+//
+// Geometries are projected to EPSG:3857, clipped to the tile bounds with
+// the layer buffer and quantized to the layer extent. Features left without
+// geometry after clipping are skipped, as well as empty layers.
+func Encode(z, x, y int, layers ...FeatureLayer) ([]byte, error) {
+	if err := ValidateTile(z, x, y); err != nil {
+		return nil, err
+	}
+
+	var tile protoBuffer
+
+	for _, layer := range layers {
+		data, err := encodeLayer(z, x, y, layer)
+		if err != nil {
+			return nil, fmt.Errorf("layer %s: %w", layer.Name, err)
+		}
+
+		if data != nil {
+			tile.bytesField(3, data)
+		}
+	}
+
+	return tile, nil
+}
+
+func encodeLayer(z, x, y int, layer FeatureLayer) (protoBuffer, error) {
+	var (
+		extent = withDefault(layer.Extent, DefaultExtent)
+		buffer = withDefault(layer.Buffer, DefaultBuffer)
+
+		transform = newTileTransform(z, x, y, extent)
+		clip      = box{min: float64(-buffer), max: float64(extent + buffer)}
+
+		encoder = newLayerEncoder()
+	)
+
+	for _, feature := range layer.Features {
+		s, err := transform.shapes(feature.Geometry)
+		if err != nil {
+			return nil, err
+		}
+
+		s = s.clip(clip)
+
+		if err = encoder.feature(feature, s); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(encoder.features) == 0 {
+		return nil, nil
+	}
+
+	var data protoBuffer
+
+	data.uintField(15, 2) // version
+	data.stringField(1, layer.Name)
+
+	for _, feature := range encoder.features {
+		data.bytesField(2, feature)
+	}
+
+	for _, key := range encoder.keys {
+		data.stringField(3, key)
+	}
+
+	for _, value := range encoder.values {
+		data.bytesField(4, value)
+	}
+
+	data.uintField(5, uint64(extent))
+
+	return data, nil
+}
+
+// layerEncoder accumulates features with deduplicated keys and values
+type layerEncoder struct {
+	keys       []string
+	keyIndex   map[string]uint32
+	values     []protoBuffer
+	valueIndex map[any]uint32
+	features   []protoBuffer
+}
+
+func newLayerEncoder() *layerEncoder {
+	return &layerEncoder{
+		keyIndex:   map[string]uint32{},
+		valueIndex: map[any]uint32{},
+	}
+}
+
+func (e *layerEncoder) feature(feature Feature, s shapes) error {
+	tags, err := e.tags(feature.Properties)
+	if err != nil {
+		return err
+	}
+
+	for _, part := range s.commands() {
+		var data protoBuffer
+
+		if feature.ID != 0 {
+			data.uintField(1, feature.ID)
+		}
+
+		data.packedField(2, tags)
+		data.uintField(3, uint64(part.geomType))
+		data.packedField(4, part.commands)
+
+		e.features = append(e.features, data)
+	}
+
+	return nil
+}
+
+func (e *layerEncoder) tags(properties map[string]any) ([]uint32, error) {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	tags := make([]uint32, 0, 2*len(keys))
+
+	for _, key := range keys {
+		value, ok, err := encodeValue(properties[key])
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", key, err)
+		}
+
+		if !ok {
+			continue
+		}
+
+		keyIndex, found := e.keyIndex[key]
+		if !found {
+			keyIndex = uint32(len(e.keys))
+			e.keyIndex[key] = keyIndex
+			e.keys = append(e.keys, key)
+		}
+
+		valueIndex, found := e.valueIndex[value.key]
+		if !found {
+			valueIndex = uint32(len(e.values))
+			e.valueIndex[value.key] = valueIndex
+			e.values = append(e.values, value.data)
+		}
+
+		tags = append(tags, keyIndex, valueIndex)
+	}
+
+	return tags, nil
+}
+
+type encodedValue struct {
+	key  any
+	data protoBuffer
+}
+
+// encodeValue converts property value to vector_tile.proto Value message,
+// ok is false for nil values which are not encoded
+func encodeValue(value any) (v encodedValue, ok bool, err error) {
+	type (
+		stringKey string
+		floatKey  float32
+		doubleKey float64
+		sintKey   int64
+		uintKey   uint64
+		boolKey   bool
+	)
+
+	switch value := value.(type) {
+	case nil:
+		return v, false, nil
+	case string:
+		v.key = stringKey(value)
+		v.data.stringField(1, value)
+	case float32:
+		v.key = floatKey(value)
+		v.data.floatField(2, value)
+	case float64:
+		v.key = doubleKey(value)
+		v.data.doubleField(3, value)
+	case int, int8, int16, int32, int64:
+		i := reflect.ValueOf(value).Int()
+		v.key = sintKey(i)
+		v.data.sintField(6, i)
+	case uint, uint8, uint16, uint32, uint64:
+		u := reflect.ValueOf(value).Uint()
+		v.key = uintKey(u)
+		v.data.uintField(5, u)
+	case bool:
+		v.key = boolKey(value)
+		v.data.boolField(7, value)
+	default:
+		return v, false, fmt.Errorf("%w: %T", ErrUnsupportedValue, value)
+	}
+
+	return v, true, nil
+}
+
+// tileTransform converts coordinates into tile screen space
+type tileTransform struct {
+	minX, maxY, size, extent float64
+}
+
+func newTileTransform(z, x, y, extent int) tileTransform {
+	size := webMercatorSize / math.Exp2(float64(z))
+
+	return tileTransform{
+		minX:   -webMercatorSize/2 + float64(x)*size,
+		maxY:   webMercatorSize/2 - float64(y)*size,
+		size:   size,
+		extent: float64(extent),
+	}
+}
+
+func (t tileTransform) projection(srid int) (func(geom.Coord) point, error) {
+	if srid == 0 {
+		srid = georm.SRID
+	}
+
+	switch srid {
+	case 4326:
+		return func(c geom.Coord) point {
+			lat := math.Max(-maxLatitude, math.Min(maxLatitude, c.Y()))
+
+			return t.pixel(
+				earthRadius*c.X()*math.Pi/180,
+				earthRadius*math.Log(math.Tan(math.Pi/4+lat*math.Pi/360)),
+			)
+		}, nil
+	case 3857:
+		return func(c geom.Coord) point { return t.pixel(c.X(), c.Y()) }, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedSRID, srid)
+	}
+}
+
+// pixel converts EPSG:3857 coordinates into tile screen space with y axis down
+func (t tileTransform) pixel(x, y float64) point {
+	return point{
+		(x - t.minX) / t.size * t.extent,
+		(t.maxY - y) / t.size * t.extent,
+	}
+}
+
+func (t tileTransform) shapes(g geom.T) (s shapes, err error) {
+	if g == nil || reflect.ValueOf(g).IsNil() {
+		return s, nil
+	}
+
+	project, err := t.projection(g.SRID())
+	if err != nil {
+		return s, err
+	}
+
+	return s, s.add(g, project)
+}
+
+type (
+	point [2]float64
+	path  []point
+)
+
+func projectPath(coords []geom.Coord, project func(geom.Coord) point) path {
+	p := make(path, len(coords))
+	for i, c := range coords {
+		p[i] = project(c)
+	}
+
+	return p
+}
+
+// shapes are geometry parts in tile screen space grouped by tile geometry type
+type shapes struct {
+	points   path
+	lines    []path
+	polygons [][]path
+}
+
+func (s *shapes) add(g geom.T, project func(geom.Coord) point) error {
+	switch g := g.(type) {
+	case *geom.Point:
+		if !g.Empty() {
+			s.points = append(s.points, project(g.Coords()))
+		}
+	case *geom.MultiPoint:
+		for i := 0; i < g.NumPoints(); i++ {
+			if err := s.add(g.Point(i), project); err != nil {
+				return err
+			}
+		}
+	case *geom.LineString:
+		s.lines = append(s.lines, projectPath(g.Coords(), project))
+	case *geom.MultiLineString:
+		for i := 0; i < g.NumLineStrings(); i++ {
+			s.lines = append(s.lines, projectPath(g.LineString(i).Coords(), project))
+		}
+	case *geom.Polygon:
+		rings := make([]path, g.NumLinearRings())
+		for i := range rings {
+			rings[i] = projectPath(g.LinearRing(i).Coords(), project)
+		}
+
+		s.polygons = append(s.polygons, rings)
+	case *geom.MultiPolygon:
+		for i := 0; i < g.NumPolygons(); i++ {
+			if err := s.add(g.Polygon(i), project); err != nil {
+				return err
+			}
+		}
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			if err := s.add(child, project); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedGeometry, g)
+	}
+
+	return nil
+}
+
+// clip clips shapes to the box, parts left outside are removed
+func (s shapes) clip(b box) (clipped shapes) {
+	for _, p := range s.points {
+		if b.contains(p) {
+			clipped.points = append(clipped.points, p)
+		}
+	}
+
+	for _, line := range s.lines {
+		clipped.lines = append(clipped.lines, b.clipLine(line)...)
+	}
+
+	for _, rings := range s.polygons {
+		var polygon []path
+
+		for i, ring := range rings {
+			ring = b.clipRing(ring)
+			if len(ring) < 3 {
+				if i == 0 {
+					break // exterior ring is outside, skip holes
+				}
+
+				continue
+			}
+
+			polygon = append(polygon, ring)
+		}
+
+		if len(polygon) > 0 {
+			clipped.polygons = append(clipped.polygons, polygon)
+		}
+	}
+
+	return clipped
+}
+
+type encodedGeometry struct {
+	geomType int
+	commands []uint32
+}
+
+// commands quantizes shapes and encodes them as geometry commands,
+// one encoded geometry per tile geometry type
+func (s shapes) commands() []encodedGeometry {
+	var encoded []encodedGeometry
+
+	if len(s.points) > 0 {
+		var e commandEncoder
+
+		e.command(cmdMoveTo, len(s.points))
+		for _, p := range s.points {
+			e.point(quantize(p))
+		}
+
+		encoded = append(encoded, encodedGeometry{geomType: typePoint, commands: e.commands})
+	}
+
+	var lines commandEncoder
+
+	for _, line := range s.lines {
+		q := quantizePath(line)
+		if len(q) < 2 {
+			continue
+		}
+
+		lines.command(cmdMoveTo, 1)
+		lines.point(q[0])
+		lines.command(cmdLineTo, len(q)-1)
+
+		for _, p := range q[1:] {
+			lines.point(p)
+		}
+	}
+
+	if len(lines.commands) > 0 {
+		encoded = append(encoded, encodedGeometry{geomType: typeLineString, commands: lines.commands})
+	}
+
+	var polygons commandEncoder
+
+	for _, rings := range s.polygons {
+		for i, ring := range rings {
+			q := quantizeRing(ring)
+
+			area := q.area()
+			if area == 0 {
+				if i == 0 {
+					break // exterior ring collapsed, skip holes
+				}
+
+				continue
+			}
+
+			// exterior rings have positive area in screen space, holes negative
+			if (i == 0) != (area > 0) {
+				slices.Reverse(q)
+			}
+
+			polygons.command(cmdMoveTo, 1)
+			polygons.point(q[0])
+			polygons.command(cmdLineTo, len(q)-1)
+
+			for _, p := range q[1:] {
+				polygons.point(p)
+			}
+
+			polygons.command(cmdClosePath, 1)
+		}
+	}
+
+	if len(polygons.commands) > 0 {
+		encoded = append(encoded, encodedGeometry{geomType: typePolygon, commands: polygons.commands})
+	}
+
+	return encoded
+}
+
+type (
+	ipoint [2]int32
+	ipath  []ipoint
+)
+
+func quantize(p point) ipoint {
+	return ipoint{int32(math.Round(p[0])), int32(math.Round(p[1]))}
+}
+
+// quantizePath rounds coordinates to integers removing repeated points
+func quantizePath(p path) ipath {
+	q := make(ipath, 0, len(p))
+
+	for _, c := range p {
+		ic := quantize(c)
+		if len(q) > 0 && q[len(q)-1] == ic {
+			continue
+		}
+
+		q = append(q, ic)
+	}
+
+	return q
+}
+
+// quantizeRing rounds ring coordinates to integers, the result is not closed
+func quantizeRing(ring path) ipath {
+	q := quantizePath(ring)
+
+	for len(q) > 1 && q[len(q)-1] == q[0] {
+		q = q[:len(q)-1]
+	}
+
+	if len(q) < 3 {
+		return nil
+	}
+
+	return q
+}
+
+// area returns doubled signed area of the ring using the surveyor's formula
+func (q ipath) area() int64 {
+	var area int64
+
+	for i := range q {
+		j := (i + 1) % len(q)
+		area += int64(q[i][0])*int64(q[j][1]) - int64(q[j][0])*int64(q[i][1])
+	}
+
+	return area
+}
+
+// commandEncoder writes geometry commands with zigzag encoded deltas
+type commandEncoder struct {
+	commands []uint32
+	cursor   ipoint
+}
+
+func (e *commandEncoder) command(id, count int) {
+	e.commands = append(e.commands, uint32(id&0x7|count<<3))
+}
+
+func (e *commandEncoder) point(p ipoint) {
+	e.commands = append(e.commands,
+		zigzag32(p[0]-e.cursor[0]),
+		zigzag32(p[1]-e.cursor[1]),
+	)
+
+	e.cursor = p
+}
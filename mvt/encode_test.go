@@ -0,0 +1,261 @@
+package mvt
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+
+	"github.com/ybru-tech/georm"
+)
+
+// examples from the vector tile specification, section 4.3.5
+func TestShapesCommands(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Shapes   shapes
+		GeomType int
+		Expect   []uint32
+	}{
+		{
+			Name:     "point",
+			Shapes:   shapes{points: path{{25, 17}}},
+			GeomType: typePoint,
+			Expect:   []uint32{9, 50, 34},
+		},
+		{
+			Name:     "multi point",
+			Shapes:   shapes{points: path{{5, 7}, {3, 2}}},
+			GeomType: typePoint,
+			Expect:   []uint32{17, 10, 14, 3, 9},
+		},
+		{
+			Name:     "line string",
+			Shapes:   shapes{lines: []path{{{2, 2}, {2, 10}, {10, 10}}}},
+			GeomType: typeLineString,
+			Expect:   []uint32{9, 4, 4, 18, 0, 16, 16, 0},
+		},
+		{
+			Name:     "multi line string",
+			Shapes:   shapes{lines: []path{{{2, 2}, {2, 10}, {10, 10}}, {{1, 1}, {3, 5}}}},
+			GeomType: typeLineString,
+			Expect:   []uint32{9, 4, 4, 18, 0, 16, 16, 0, 9, 17, 17, 10, 4, 8},
+		},
+		{
+			Name:     "polygon",
+			Shapes:   shapes{polygons: [][]path{{{{3, 6}, {8, 12}, {20, 34}, {3, 6}}}}},
+			GeomType: typePolygon,
+			Expect:   []uint32{9, 6, 12, 18, 10, 12, 24, 44, 15},
+		},
+		{
+			Name:     "polygon with wrong winding order",
+			Shapes:   shapes{polygons: [][]path{{{{20, 34}, {8, 12}, {3, 6}, {20, 34}}}}},
+			GeomType: typePolygon,
+			Expect:   []uint32{9, 6, 12, 18, 10, 12, 24, 44, 15},
+		},
+		{
+			Name: "multi polygon with hole",
+			Shapes: shapes{polygons: [][]path{
+				{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+				{
+					{{11, 11}, {20, 11}, {20, 20}, {11, 20}, {11, 11}},
+					{{13, 13}, {13, 17}, {17, 17}, {17, 13}, {13, 13}},
+				},
+			}},
+			GeomType: typePolygon,
+			Expect: []uint32{
+				9, 0, 0, 26, 20, 0, 0, 20, 19, 0, 15,
+				9, 22, 2, 26, 18, 0, 0, 18, 17, 0, 15,
+				9, 4, 13, 26, 0, 8, 8, 0, 0, 7, 15,
+			},
+		},
+		{
+			Name:     "collapsed polygon",
+			Shapes:   shapes{polygons: [][]path{{{{0, 0}, {0.1, 0.1}, {0.2, 0}, {0, 0}}}}},
+			GeomType: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			encoded := test.Shapes.commands()
+
+			if test.GeomType == 0 {
+				assert.Empty(t, encoded)
+				return
+			}
+
+			require.Len(t, encoded, 1)
+			assert.Equal(t, test.GeomType, encoded[0].geomType)
+			assert.Equal(t, test.Expect, encoded[0].commands)
+		})
+	}
+}
+
+func TestBoxClipLine(t *testing.T) {
+	b := box{min: 0, max: 10}
+
+	tests := []struct {
+		Name   string
+		Line   path
+		Expect []path
+	}{
+		{Name: "inside", Line: path{{1, 1}, {5, 5}, {9, 1}}, Expect: []path{{{1, 1}, {5, 5}, {9, 1}}}},
+		{Name: "outside", Line: path{{-5, -5}, {-1, 20}}, Expect: nil},
+		{Name: "crossing", Line: path{{-5, 5}, {15, 5}}, Expect: []path{{{0, 5}, {10, 5}}}},
+		{
+			Name:   "leaving and entering",
+			Line:   path{{5, 5}, {5, 15}, {8, 15}, {8, 5}},
+			Expect: []path{{{5, 5}, {5, 10}}, {{8, 10}, {8, 5}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expect, b.clipLine(test.Line))
+		})
+	}
+}
+
+func TestBoxClipRing(t *testing.T) {
+	b := box{min: 0, max: 10}
+
+	assert.Equal(t,
+		path{{5, 10}, {5, 5}, {10, 5}, {10, 10}},
+		b.clipRing(path{{5, 5}, {15, 5}, {15, 15}, {5, 15}, {5, 5}}),
+	)
+
+	assert.Empty(t, b.clipRing(path{{20, 20}, {30, 20}, {30, 30}, {20, 20}}))
+}
+
+func TestEncode(t *testing.T) {
+	features := []Feature{
+		NewFeature(1, georm.New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{0, 0}).SetSRID(4326)), map[string]any{
+			"title": "center",
+			"rank":  1,
+		}),
+		NewFeature(2, georm.New(geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{-180, 0}, {180, 0}})), map[string]any{
+			"title": "equator",
+			"rank":  int64(1),
+			"empty": nil,
+		}),
+		NewFeature(3, georm.New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{0, 0}).SetSRID(3857)), map[string]any{
+			"visible": true,
+			"ratio":   0.5,
+		}),
+	}
+
+	data, err := Encode(0, 0, 0, FeatureLayer{Name: "layer", Extent: 256, Buffer: 0, Features: features})
+	require.NoError(t, err)
+
+	tile := decodeMessage(t, data)
+	require.Len(t, tile[3], 1)
+
+	layer := decodeMessage(t, tile[3][0].([]byte))
+	assert.Equal(t, []any{uint64(2)}, layer[15])
+	assert.Equal(t, []any{[]byte("layer")}, layer[1])
+	assert.Equal(t, []any{uint64(256)}, layer[5])
+	assert.Equal(t, []any{[]byte("rank"), []byte("title"), []byte("ratio"), []byte("visible")}, layer[3])
+	assert.Len(t, layer[4], 5) // 1, "center", "equator", 0.5, true
+
+	require.Len(t, layer[2], 3)
+
+	point := decodeMessage(t, layer[2][0].([]byte))
+	assert.Equal(t, []any{uint64(1)}, point[1])
+	assert.Equal(t, []any{uint64(typePoint)}, point[3])
+	assert.Equal(t, []uint32{0, 0, 1, 1}, decodePacked(t, point[2][0].([]byte)))
+	assert.Equal(t, []uint32{9, 256, 256}, decodePacked(t, point[4][0].([]byte)))
+
+	line := decodeMessage(t, layer[2][1].([]byte))
+	assert.Equal(t, []any{uint64(typeLineString)}, line[3])
+	assert.Equal(t, []uint32{0, 0, 1, 2}, decodePacked(t, line[2][0].([]byte)))
+	assert.Equal(t, []uint32{9, 0, 256, 10, 512, 0}, decodePacked(t, line[4][0].([]byte)))
+
+	mercatorPoint := decodeMessage(t, layer[2][2].([]byte))
+	assert.Equal(t, []uint32{2, 3, 3, 4}, decodePacked(t, mercatorPoint[2][0].([]byte)))
+}
+
+func TestEncodeSkipsEmptyLayers(t *testing.T) {
+	point := NewFeature(0, georm.New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{-100, 40}).SetSRID(4326)), nil)
+
+	// point is in the western hemisphere, tile 1/1/0 is the north-east one
+	data, err := Encode(1, 1, 0, FeatureLayer{Name: "layer", Features: []Feature{point}})
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	data, err = Encode(1, 0, 0, FeatureLayer{Name: "layer", Features: []Feature{point}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, data)
+}
+
+func TestEncodeExpectErrors(t *testing.T) {
+	_, err := Encode(1, 2, 0)
+	assert.ErrorIs(t, err, ErrInvalidTile)
+
+	_, err = Encode(0, 0, 0, FeatureLayer{Features: []Feature{
+		{Geometry: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{0, 0}).SetSRID(2154)},
+	}})
+	assert.ErrorIs(t, err, ErrUnsupportedSRID)
+
+	_, err = Encode(0, 0, 0, FeatureLayer{Features: []Feature{
+		{Geometry: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{0, 0}), Properties: map[string]any{"tags": []string{}}},
+	}})
+	assert.ErrorIs(t, err, ErrUnsupportedValue)
+}
+
+// decodeMessage decodes protobuf message into values grouped by field number,
+// varints are returned as uint64 and length delimited fields as []byte
+func decodeMessage(t *testing.T, data []byte) map[int][]any {
+	t.Helper()
+
+	message := map[int][]any{}
+
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		require.Positive(t, n)
+		data = data[n:]
+
+		field := int(key >> 3)
+
+		switch key & 0x7 {
+		case wireVarint:
+			v, n := binary.Uvarint(data)
+			require.Positive(t, n)
+			data = data[n:]
+			message[field] = append(message[field], v)
+		case wireFixed64:
+			message[field] = append(message[field], data[:8])
+			data = data[8:]
+		case wireFixed32:
+			message[field] = append(message[field], data[:4])
+			data = data[4:]
+		case wireBytes:
+			size, n := binary.Uvarint(data)
+			require.Positive(t, n)
+			data = data[n:]
+			message[field] = append(message[field], data[:size])
+			data = data[size:]
+		default:
+			t.Fatalf("unexpected wire type %d", key&0x7)
+		}
+	}
+
+	return message
+}
+
+func decodePacked(t *testing.T, data []byte) []uint32 {
+	t.Helper()
+
+	var values []uint32
+
+	for len(data) > 0 {
+		v, n := binary.Uvarint(data)
+		require.Positive(t, n)
+		data = data[n:]
+		values = append(values, uint32(v))
+	}
+
+	return values
+}
@@ -0,0 +1,76 @@
+package mvt
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const ContentType = "application/vnd.mapbox-vector-tile"
+
+// Handler serves tiles of the layer over http.
+//
+// Tile coordinates are taken from the {z}, {x} and {y} path wildcards when the
+// handler is registered with http.ServeMux patterns, otherwise from the last
+// three segments of the url path, e.g. /tiles/zones/12/2476/1280.pbf
+func Handler(db *gorm.DB, layer Layer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		z, x, y, err := ParseTilePath(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		tile, err := Tile(db.WithContext(r.Context()), layer, z, x, y)
+		if err != nil {
+			if errors.Is(err, ErrInvalidTile) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", ContentType)
+
+		if len(tile) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		_, _ = w.Write(tile)
+	})
+}
+
+// ParseTilePath extracts z/x/y tile coordinates from the request
+func ParseTilePath(r *http.Request) (z, x, y int, err error) {
+	values := [3]string{r.PathValue("z"), r.PathValue("x"), r.PathValue("y")}
+
+	if values[0] == "" || values[1] == "" || values[2] == "" {
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(segments) < 3 {
+			return 0, 0, 0, fmt.Errorf("%w: %s", ErrInvalidTile, r.URL.Path)
+		}
+
+		copy(values[:], segments[len(segments)-3:])
+	}
+
+	// strip extension: 1280.pbf, 1280.mvt
+	if i := strings.IndexByte(values[2], '.'); i >= 0 {
+		values[2] = values[2][:i]
+	}
+
+	var coords [3]int
+	for i, value := range values {
+		if coords[i], err = strconv.Atoi(value); err != nil {
+			return 0, 0, 0, fmt.Errorf("%w: %s", ErrInvalidTile, r.URL.Path)
+		}
+	}
+
+	return coords[0], coords[1], coords[2], ValidateTile(coords[0], coords[1], coords[2])
+}
@@ -0,0 +1,64 @@
+package mvt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTilePath(t *testing.T) {
+	tests := []struct {
+		Path    string
+		Z, X, Y int
+		Error   error
+	}{
+		{Path: "/tiles/12/2476/1280", Z: 12, X: 2476, Y: 1280},
+		{Path: "/tiles/zones/3/4/5.pbf", Z: 3, X: 4, Y: 5},
+		{Path: "/0/0/0.mvt", Z: 0, X: 0, Y: 0},
+		{Path: "/tiles/1/2", Error: ErrInvalidTile},
+		{Path: "/tiles/a/b/c", Error: ErrInvalidTile},
+		{Path: "/tiles/1/2/0", Error: ErrInvalidTile},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Path, func(t *testing.T) {
+			z, x, y, err := ParseTilePath(httptest.NewRequest(http.MethodGet, test.Path, nil))
+
+			if test.Error != nil {
+				assert.ErrorIs(t, err, test.Error)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, [3]int{test.Z, test.X, test.Y}, [3]int{z, x, y})
+		})
+	}
+}
+
+func TestParseTilePathWildcards(t *testing.T) {
+	var z, x, y int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tiles/{z}/{x}/{y}/tile.pbf", func(w http.ResponseWriter, r *http.Request) {
+		var err error
+
+		z, x, y, err = ParseTilePath(r)
+		require.NoError(t, err)
+	})
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tiles/3/4/5/tile.pbf", nil))
+
+	assert.Equal(t, [3]int{3, 4, 5}, [3]int{z, x, y})
+}
+
+func TestHandlerExpectBadRequest(t *testing.T) {
+	handler := Handler(nil, Layer{Model: &Zone{}, Geometry: "GeoPolygon"})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/tiles/1/5/5", nil))
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -0,0 +1,81 @@
+package mvt
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// protobuf wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// protoBuffer is a minimal protobuf writer covering vector_tile.proto needs
+type protoBuffer []byte
+
+func (b *protoBuffer) varint(v uint64) {
+	*b = binary.AppendUvarint(*b, v)
+}
+
+func (b *protoBuffer) key(field, wire int) {
+	b.varint(uint64(field<<3 | wire))
+}
+
+func (b *protoBuffer) uintField(field int, v uint64) {
+	b.key(field, wireVarint)
+	b.varint(v)
+}
+
+func (b *protoBuffer) sintField(field int, v int64) {
+	b.key(field, wireVarint)
+	b.varint(zigzag64(v))
+}
+
+func (b *protoBuffer) boolField(field int, v bool) {
+	var value uint64
+	if v {
+		value = 1
+	}
+
+	b.uintField(field, value)
+}
+
+func (b *protoBuffer) floatField(field int, v float32) {
+	b.key(field, wireFixed32)
+	*b = binary.LittleEndian.AppendUint32(*b, math.Float32bits(v))
+}
+
+func (b *protoBuffer) doubleField(field int, v float64) {
+	b.key(field, wireFixed64)
+	*b = binary.LittleEndian.AppendUint64(*b, math.Float64bits(v))
+}
+
+func (b *protoBuffer) bytesField(field int, v []byte) {
+	b.key(field, wireBytes)
+	b.varint(uint64(len(v)))
+	*b = append(*b, v...)
+}
+
+func (b *protoBuffer) stringField(field int, v string) {
+	b.bytesField(field, []byte(v))
+}
+
+func (b *protoBuffer) packedField(field int, values []uint32) {
+	if len(values) == 0 {
+		return
+	}
+
+	var packed protoBuffer
+	for _, v := range values {
+		packed.varint(uint64(v))
+	}
+
+	b.bytesField(field, packed)
+}
+
+func zigzag32(v int32) uint32 { return uint32((v << 1) ^ (v >> 31)) }
+
+func zigzag64(v int64) uint64 { return uint64((v << 1) ^ (v >> 63)) }
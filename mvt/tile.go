@@ -0,0 +1,160 @@
+package mvt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ybru-tech/georm"
+)
+
+var (
+	ErrInvalidTile   = errors.New("invalid tile coordinates")
+	ErrFieldNotFound = errors.New("field not found in model")
+)
+
+const (
+	DefaultExtent = 4096
+	DefaultBuffer = 256
+
+	// MaxZoom is the deepest zoom level accepted by ST_TileEnvelope
+	MaxZoom = 30
+
+	// webMercatorSize is the width of the EPSG:3857 world square in meters
+	webMercatorSize = 2 * 20037508.342789244
+)
+
+// Layer describes how rows of a gorm model are rendered into a tile layer
+type Layer struct {
+	// Model is a gorm model, e.g. &Zone{}
+	Model any
+
+	// Name of the layer inside the tile, table name by default
+	Name string
+
+	// Geometry is the struct field or column name holding the geometry
+	Geometry string
+
+	// Attributes are struct fields or columns exported as feature properties
+	Attributes []string
+
+	// SRID of the geometry column, georm.SRID by default
+	SRID int
+
+	// Extent is the tile size in screen units, DefaultExtent by default
+	Extent int
+
+	// Buffer is the clipping buffer in screen units, DefaultBuffer by default
+	Buffer int
+
+	// Simplify sets the simplification tolerance in screen units,
+	// 0 disables simplification
+	Simplify float64
+
+	// Scopes are applied to the source query, e.g. for additional filters
+	Scopes []func(*gorm.DB) *gorm.DB
+}
+
+// Query builds the ST_AsMVT query of a single tile for the layer
+func Query(db *gorm.DB, layer Layer, z, x, y int) (*gorm.DB, error) {
+	if err := ValidateTile(z, x, y); err != nil {
+		return nil, err
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(layer.Model); err != nil {
+		return nil, err
+	}
+
+	geometry := stmt.Schema.LookUpField(layer.Geometry)
+	if geometry == nil || geometry.DBName == "" {
+		return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, layer.Geometry)
+	}
+
+	columns := make([]string, 0, len(layer.Attributes)+1)
+	for _, name := range layer.Attributes {
+		field := stmt.Schema.LookUpField(name)
+		if field == nil || field.DBName == "" {
+			return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, name)
+		}
+
+		columns = append(columns, stmt.Quote(clause.Column{Table: clause.CurrentTable, Name: field.DBName}))
+	}
+
+	var (
+		name   = withDefault(layer.Name, stmt.Schema.Table)
+		srid   = withDefault(layer.SRID, georm.SRID)
+		extent = withDefault(layer.Extent, DefaultExtent)
+		buffer = withDefault(layer.Buffer, DefaultBuffer)
+
+		column   = stmt.Quote(clause.Column{Table: clause.CurrentTable, Name: geometry.DBName})
+		envelope = fmt.Sprintf("ST_TileEnvelope(%d, %d, %d)", z, x, y)
+		source   = fmt.Sprintf("ST_Transform(%s, 3857)", column)
+	)
+
+	if layer.Simplify > 0 {
+		tolerance := layer.Simplify * webMercatorSize / math.Exp2(float64(z)) / float64(extent)
+		source = fmt.Sprintf("ST_Simplify(%s, %s, true)", source, formatFloat(tolerance))
+	}
+
+	columns = append(columns,
+		fmt.Sprintf("ST_AsMVTGeom(%s, %s, %d, %d, true) AS %s", source, envelope, extent, buffer, stmt.Quote("geom")))
+
+	features := db.
+		Model(layer.Model).
+		Scopes(layer.Scopes...).
+		Select(strings.Join(columns, ", ")).
+		Where(fmt.Sprintf("%s && ST_Transform(%s, %d)", column, envelope, srid))
+
+	tile := db.
+		Table("(?) AS mvtgeom", features).
+		Select("ST_AsMVT(mvtgeom.*, ?, ?, 'geom')", name, extent)
+
+	return tile, nil
+}
+
+// Tile renders a single tile of the layer and returns Mapbox Vector Tile bytes
+func Tile(db *gorm.DB, layer Layer, z, x, y int) ([]byte, error) {
+	query, err := Query(db, layer, z, x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	var tile []byte
+	if err = query.Row().Scan(&tile); err != nil {
+		return nil, err
+	}
+
+	return tile, nil
+}
+
+// ValidateTile checks that z/x/y addresses an existing tile
+func ValidateTile(z, x, y int) error {
+	if z < 0 || z > MaxZoom {
+		return fmt.Errorf("%w: zoom %d", ErrInvalidTile, z)
+	}
+
+	size := 1 << z
+	if x < 0 || x >= size || y < 0 || y >= size {
+		return fmt.Errorf("%w: %d/%d/%d", ErrInvalidTile, z, x, y)
+	}
+
+	return nil
+}
+
+func withDefault[T comparable](value, fallback T) T {
+	var zero T
+	if value == zero {
+		return fallback
+	}
+
+	return value
+}
+
+func formatFloat(value float64) string {
+	return fmt.Sprintf("%g", value)
+}
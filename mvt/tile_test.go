@@ -0,0 +1,118 @@
+package mvt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/ybru-tech/georm"
+)
+
+type Zone struct {
+	ID         uint `gorm:"primaryKey"`
+	Title      string
+	GeoPolygon georm.Polygon
+}
+
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestQuery(t *testing.T) {
+	db := dryRunDB(t)
+
+	tests := []struct {
+		Name   string
+		Layer  Layer
+		Expect string
+	}{
+		{
+			Name:  "defaults",
+			Layer: Layer{Model: &Zone{}, Geometry: "GeoPolygon", Attributes: []string{"ID", "title"}},
+			Expect: `SELECT ST_AsMVT(mvtgeom.*, 'zones', 4096, 'geom') FROM (` +
+				`SELECT "zones"."id", "zones"."title", ` +
+				`ST_AsMVTGeom(ST_Transform("zones"."geo_polygon", 3857), ST_TileEnvelope(1, 0, 1), 4096, 256, true) AS "geom" ` +
+				`FROM "zones" WHERE "zones"."geo_polygon" && ST_Transform(ST_TileEnvelope(1, 0, 1), 4326)) AS mvtgeom`,
+		},
+		{
+			Name: "custom layer with simplification",
+			Layer: Layer{
+				Model:    &Zone{},
+				Name:     "zone",
+				Geometry: "geo_polygon",
+				SRID:     3857,
+				Extent:   512,
+				Buffer:   64,
+				Simplify: 1,
+				Scopes: []func(*gorm.DB) *gorm.DB{
+					func(db *gorm.DB) *gorm.DB { return db.Where("title <> ?", "") },
+				},
+			},
+			Expect: `SELECT ST_AsMVT(mvtgeom.*, 'zone', 512, 'geom') FROM (` +
+				`SELECT ST_AsMVTGeom(ST_Simplify(ST_Transform("zones"."geo_polygon", 3857), 39135.75848201024, true), ` +
+				`ST_TileEnvelope(1, 0, 1), 512, 64, true) AS "geom" ` +
+				`FROM "zones" WHERE "zones"."geo_polygon" && ST_Transform(ST_TileEnvelope(1, 0, 1), 3857) AND title <> '') AS mvtgeom`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			actual := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				query, err := Query(tx, test.Layer, 1, 0, 1)
+				require.NoError(t, err)
+
+				var tile []byte
+				return query.Scan(&tile)
+			})
+
+			assert.Equal(t, test.Expect, actual)
+		})
+	}
+}
+
+func TestQueryExpectErrFieldNotFound(t *testing.T) {
+	db := dryRunDB(t)
+
+	_, err := Query(db, Layer{Model: &Zone{}, Geometry: "unknown"}, 0, 0, 0)
+	require.ErrorIs(t, err, ErrFieldNotFound)
+
+	_, err = Query(db, Layer{Model: &Zone{}, Geometry: "GeoPolygon", Attributes: []string{"unknown"}}, 0, 0, 0)
+	require.ErrorIs(t, err, ErrFieldNotFound)
+}
+
+func TestValidateTile(t *testing.T) {
+	tests := []struct {
+		Z, X, Y int
+		Valid   bool
+	}{
+		{Z: 0, X: 0, Y: 0, Valid: true},
+		{Z: 1, X: 1, Y: 1, Valid: true},
+		{Z: 12, X: 2476, Y: 1280, Valid: true},
+		{Z: -1, X: 0, Y: 0},
+		{Z: 31, X: 0, Y: 0},
+		{Z: 1, X: 2, Y: 0},
+		{Z: 1, X: 0, Y: -1},
+	}
+
+	for _, test := range tests {
+		err := ValidateTile(test.Z, test.X, test.Y)
+		if test.Valid {
+			assert.NoError(t, err, "%d/%d/%d", test.Z, test.X, test.Y)
+		} else {
+			assert.ErrorIs(t, err, ErrInvalidTile, "%d/%d/%d", test.Z, test.X, test.Y)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package ogcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/ybru-tech/georm"
+)
+
+var (
+	ErrFieldNotFound       = errors.New("field not found in model")
+	ErrNoGeometryField     = errors.New("model has no geometry field")
+	ErrNoPrimaryKey        = errors.New("model has no primary key")
+	ErrDatetimeUnsupported = errors.New("datetime filter is not supported by the collection")
+)
+
+// Collection describes a gorm model exposed as a feature collection.
+//
+// Geometries are expected to be stored in EPSG:4326 which matches CRS84
+// coordinates of the OGC API
+type Collection struct {
+	// ID is the collection identifier used in urls, table name by default
+	ID string
+
+	Title       string
+	Description string
+
+	// Model is a gorm model, e.g. &Zone{}
+	Model any
+
+	// Geometry is the struct field or column name holding the feature
+	// geometry, the first georm geometry field by default
+	Geometry string
+
+	// Datetime is the struct field or column name used by datetime filter,
+	// datetime filter is not supported when empty
+	Datetime string
+
+	// Properties are struct fields or columns exported as feature properties,
+	// all fields except primary key and geometry by default
+	Properties []string
+
+	// Scopes are applied to every query of the collection
+	Scopes []func(*gorm.DB) *gorm.DB
+}
+
+// collection is a Collection resolved against gorm schema
+type collection struct {
+	Collection
+
+	schema     *schema.Schema
+	primary    *schema.Field
+	geometry   *schema.Field
+	datetime   *schema.Field
+	properties []*schema.Field
+}
+
+func newCollection(db *gorm.DB, c Collection) (*collection, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(c.Model); err != nil {
+		return nil, err
+	}
+
+	resolved := &collection{
+		Collection: c,
+		schema:     stmt.Schema,
+		primary:    stmt.Schema.PrioritizedPrimaryField,
+	}
+
+	if resolved.ID == "" {
+		resolved.ID = stmt.Schema.Table
+	}
+
+	if resolved.Title == "" {
+		resolved.Title = resolved.ID
+	}
+
+	if resolved.primary == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoPrimaryKey, stmt.Schema.Name)
+	}
+
+	var err error
+
+	if c.Geometry != "" {
+		if resolved.geometry, err = lookUpField(stmt.Schema, c.Geometry); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, field := range stmt.Schema.Fields {
+			if isGeometryField(field) {
+				resolved.geometry = field
+				break
+			}
+		}
+
+		if resolved.geometry == nil {
+			return nil, fmt.Errorf("%w: %s", ErrNoGeometryField, stmt.Schema.Name)
+		}
+	}
+
+	if c.Datetime != "" {
+		if resolved.datetime, err = lookUpField(stmt.Schema, c.Datetime); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(c.Properties) > 0 {
+		for _, name := range c.Properties {
+			field, err := lookUpField(stmt.Schema, name)
+			if err != nil {
+				return nil, err
+			}
+
+			resolved.properties = append(resolved.properties, field)
+		}
+	} else {
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" || field == resolved.primary || field == resolved.geometry {
+				continue
+			}
+
+			resolved.properties = append(resolved.properties, field)
+		}
+	}
+
+	return resolved, nil
+}
+
+func lookUpField(s *schema.Schema, name string) (*schema.Field, error) {
+	field := s.LookUpField(name)
+	if field == nil || field.DBName == "" {
+		return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, name)
+	}
+
+	return field, nil
+}
+
+// isGeometryField reports whether field data type is a PostGIS geometry,
+// see georm.Geometry.GormDataType
+func isGeometryField(field *schema.Field) bool {
+	return strings.HasPrefix(strings.ToLower(string(field.DataType)), "geometry")
+}
+
+func (c *collection) column(field *schema.Field) clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+}
+
+// query returns a new query of the collection items matching params filters
+func (c *collection) query(db *gorm.DB, params ItemsParams) (*gorm.DB, error) {
+	tx := db.Model(c.Model).Scopes(c.Scopes...)
+
+	if params.BBox != nil {
+		tx = tx.Where(georm.InBBox(c.schema.Table+"."+c.geometry.DBName,
+			params.BBox.MinX, params.BBox.MinY, params.BBox.MaxX, params.BBox.MaxY, georm.GeoJSONSRID))
+	}
+
+	if params.Datetime != nil {
+		if c.datetime == nil {
+			return nil, ErrDatetimeUnsupported
+		}
+
+		if params.Datetime.Start != nil {
+			tx = tx.Where("? >= ?", c.column(c.datetime), *params.Datetime.Start)
+		}
+
+		if params.Datetime.End != nil {
+			tx = tx.Where("? <= ?", c.column(c.datetime), *params.Datetime.End)
+		}
+	}
+
+	return tx, nil
+}
+
+// parseID converts feature id from url to the primary key type
+func (c *collection) parseID(value string) (any, bool) {
+	switch c.primary.FieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		id, err := strconv.ParseInt(value, 10, 64)
+		return id, err == nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		id, err := strconv.ParseUint(value, 10, 64)
+		return id, err == nil
+	default:
+		return value, true
+	}
+}
+
+// newSlice returns pointer to a new empty slice of the model type
+func (c *collection) newSlice() reflect.Value {
+	return reflect.New(reflect.SliceOf(c.schema.ModelType))
+}
+
+func (c *collection) feature(ctx context.Context, value reflect.Value) feature {
+	id, _ := c.primary.ValueOf(ctx, value)
+	geometry, _ := c.geometry.ValueOf(ctx, value)
+
+	properties := make(map[string]any, len(c.properties))
+	for _, field := range c.properties {
+		properties[field.DBName], _ = field.ValueOf(ctx, value)
+	}
+
+	return feature{
+		Type:       "Feature",
+		ID:         id,
+		Geometry:   geometry,
+		Properties: properties,
+	}
+}
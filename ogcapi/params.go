@@ -0,0 +1,149 @@
+package ogcapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+// BBox is a bounding box in CRS84 coordinates
+type BBox struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Interval is a datetime filter, nil bounds are open (..)
+type Interval struct {
+	Start, End *time.Time
+}
+
+// ItemsParams are query parameters of the items request
+type ItemsParams struct {
+	Limit    int
+	Offset   int
+	BBox     *BBox
+	Datetime *Interval
+}
+
+func parseItemsParams(query url.Values, config Config) (params ItemsParams, err error) {
+	params.Limit = config.DefaultLimit
+
+	if value := query.Get("limit"); value != "" {
+		if params.Limit, err = strconv.Atoi(value); err != nil || params.Limit < 1 {
+			return params, fmt.Errorf("%w: limit %q", ErrInvalidParameter, value)
+		}
+
+		params.Limit = min(params.Limit, config.MaxLimit)
+	}
+
+	if value := query.Get("offset"); value != "" {
+		if params.Offset, err = strconv.Atoi(value); err != nil || params.Offset < 0 {
+			return params, fmt.Errorf("%w: offset %q", ErrInvalidParameter, value)
+		}
+	}
+
+	if value := query.Get("bbox"); value != "" {
+		if params.BBox, err = ParseBBox(value); err != nil {
+			return params, err
+		}
+	}
+
+	if value := query.Get("datetime"); value != "" {
+		if params.Datetime, err = ParseDatetime(value); err != nil {
+			return params, err
+		}
+	}
+
+	return params, nil
+}
+
+// ParseBBox parses bbox parameter: minx,miny,maxx,maxy or minx,miny,minz,maxx,maxy,maxz,
+// minx > maxx for the box crossing the antimeridian
+func ParseBBox(value string) (*BBox, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 && len(parts) != 6 {
+		return nil, fmt.Errorf("%w: bbox %q", ErrInvalidParameter, value)
+	}
+
+	numbers := make([]float64, len(parts))
+	for i, part := range parts {
+		number, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bbox %q", ErrInvalidParameter, value)
+		}
+
+		numbers[i] = number
+	}
+
+	// drop z values
+	if len(numbers) == 6 {
+		numbers = []float64{numbers[0], numbers[1], numbers[3], numbers[4]}
+	}
+
+	bbox := &BBox{MinX: numbers[0], MinY: numbers[1], MaxX: numbers[2], MaxY: numbers[3]}
+
+	if bbox.MinY > bbox.MaxY {
+		return nil, fmt.Errorf("%w: bbox %q", ErrInvalidParameter, value)
+	}
+
+	return bbox, nil
+}
+
+// ParseDatetime parses datetime parameter: instant, closed or half-bounded interval
+//
+//	2018-02-12T23:20:50Z
+//	2018-02-12T00:00:00Z/2018-03-18T12:31:12Z
+//	2018-02-12T00:00:00Z/..
+//	../2018-03-18T12:31:12Z
+func ParseDatetime(value string) (*Interval, error) {
+	start, end, isInterval := strings.Cut(value, "/")
+
+	if !isInterval {
+		instant, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: datetime %q", ErrInvalidParameter, value)
+		}
+
+		return &Interval{Start: &instant, End: &instant}, nil
+	}
+
+	var (
+		interval Interval
+		err      error
+	)
+
+	if interval.Start, err = parseIntervalBound(start); err != nil {
+		return nil, fmt.Errorf("%w: datetime %q", ErrInvalidParameter, value)
+	}
+
+	if interval.End, err = parseIntervalBound(end); err != nil {
+		return nil, fmt.Errorf("%w: datetime %q", ErrInvalidParameter, value)
+	}
+
+	if interval.Start == nil && interval.End == nil {
+		return nil, fmt.Errorf("%w: datetime %q", ErrInvalidParameter, value)
+	}
+
+	if interval.Start != nil && interval.End != nil && interval.Start.After(*interval.End) {
+		return nil, fmt.Errorf("%w: datetime %q", ErrInvalidParameter, value)
+	}
+
+	return &interval, nil
+}
+
+func parseIntervalBound(value string) (*time.Time, error) {
+	if value == ".." || value == "" {
+		return nil, nil
+	}
+
+	bound, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bound, nil
+}
@@ -0,0 +1,112 @@
+package ogcapi
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBBox(t *testing.T) {
+	tests := []struct {
+		Value  string
+		Expect *BBox
+	}{
+		{Value: "1,2,3,4", Expect: &BBox{MinX: 1, MinY: 2, MaxX: 3, MaxY: 4}},
+		{Value: "-10.5, 20, 30.25, 40", Expect: &BBox{MinX: -10.5, MinY: 20, MaxX: 30.25, MaxY: 40}},
+		{Value: "1,2,0,3,4,100", Expect: &BBox{MinX: 1, MinY: 2, MaxX: 3, MaxY: 4}},
+		{Value: "1,2,3"},
+		{Value: "1,2,3,a"},
+		{Value: "170,2,-170,4", Expect: &BBox{MinX: 170, MinY: 2, MaxX: -170, MaxY: 4}},
+		{Value: "1,4,3,2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Value, func(t *testing.T) {
+			actual, err := ParseBBox(test.Value)
+
+			if test.Expect == nil {
+				assert.ErrorIs(t, err, ErrInvalidParameter)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.Expect, actual)
+		})
+	}
+}
+
+func TestParseDatetime(t *testing.T) {
+	var (
+		start = time.Date(2018, 2, 12, 0, 0, 0, 0, time.UTC)
+		end   = time.Date(2018, 3, 18, 12, 31, 12, 0, time.UTC)
+	)
+
+	tests := []struct {
+		Value  string
+		Expect *Interval
+	}{
+		{Value: "2018-02-12T00:00:00Z", Expect: &Interval{Start: &start, End: &start}},
+		{Value: "2018-02-12T00:00:00Z/2018-03-18T12:31:12Z", Expect: &Interval{Start: &start, End: &end}},
+		{Value: "2018-02-12T00:00:00Z/..", Expect: &Interval{Start: &start}},
+		{Value: "../2018-03-18T12:31:12Z", Expect: &Interval{End: &end}},
+		{Value: "/2018-03-18T12:31:12Z", Expect: &Interval{End: &end}},
+		{Value: "../.."},
+		{Value: "2018-03-18T12:31:12Z/2018-02-12T00:00:00Z"},
+		{Value: "2018-02-12"},
+		{Value: "2018-02-12T00:00:00Z/tomorrow"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Value, func(t *testing.T) {
+			actual, err := ParseDatetime(test.Value)
+
+			if test.Expect == nil {
+				assert.ErrorIs(t, err, ErrInvalidParameter)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.Expect, actual)
+		})
+	}
+}
+
+func TestParseItemsParams(t *testing.T) {
+	config := Config{DefaultLimit: 10, MaxLimit: 100}
+
+	tests := []struct {
+		Query  string
+		Expect ItemsParams
+		Error  bool
+	}{
+		{Query: "", Expect: ItemsParams{Limit: 10}},
+		{Query: "limit=5&offset=20", Expect: ItemsParams{Limit: 5, Offset: 20}},
+		{Query: "limit=500", Expect: ItemsParams{Limit: 100}},
+		{Query: "bbox=1,2,3,4", Expect: ItemsParams{Limit: 10, BBox: &BBox{MinX: 1, MinY: 2, MaxX: 3, MaxY: 4}}},
+		{Query: "limit=0", Error: true},
+		{Query: "limit=ten", Error: true},
+		{Query: "offset=-1", Error: true},
+		{Query: "bbox=1,2", Error: true},
+		{Query: "datetime=yesterday", Error: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Query, func(t *testing.T) {
+			query, err := url.ParseQuery(test.Query)
+			require.NoError(t, err)
+
+			actual, err := parseItemsParams(query, config)
+
+			if test.Error {
+				assert.ErrorIs(t, err, ErrInvalidParameter)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.Expect, actual)
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package ogcapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var ErrDuplicateCollection = errors.New("duplicate collection id")
+
+const (
+	ContentTypeJSON    = "application/json"
+	ContentTypeGeoJSON = "application/geo+json"
+
+	CRS84 = "http://www.opengis.net/def/crs/OGC/1.3/CRS84"
+)
+
+// ConformanceClasses implemented by the server
+var ConformanceClasses = []string{
+	"http://www.opengis.net/spec/ogcapi-features-1/1.0/conf/core",
+	"http://www.opengis.net/spec/ogcapi-features-1/1.0/conf/geojson",
+}
+
+// Config of the OGC API Features server
+type Config struct {
+	Title       string
+	Description string
+
+	// BaseURL is used to build absolute links, taken from the request by default
+	BaseURL string
+
+	// DefaultLimit of items per page, 10 by default
+	DefaultLimit int
+
+	// MaxLimit of items per page, larger limits are reduced to it, 1000 by default
+	MaxLimit int
+}
+
+// Server serves gorm models as OGC API Features collections
+type Server struct {
+	db          *gorm.DB
+	config      Config
+	collections []*collection
+	byID        map[string]*collection
+	mux         *http.ServeMux
+}
+
+// NewServer creates OGC API Features server over the collections
+func NewServer(db *gorm.DB, config Config, collections ...Collection) (*Server, error) {
+	if config.DefaultLimit == 0 {
+		config.DefaultLimit = 10
+	}
+
+	if config.MaxLimit == 0 {
+		config.MaxLimit = 1000
+	}
+
+	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+
+	s := &Server{
+		db:     db,
+		config: config,
+		byID:   make(map[string]*collection, len(collections)),
+		mux:    http.NewServeMux(),
+	}
+
+	for _, c := range collections {
+		resolved, err := newCollection(db, c)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := s.byID[resolved.ID]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateCollection, resolved.ID)
+		}
+
+		s.collections = append(s.collections, resolved)
+		s.byID[resolved.ID] = resolved
+	}
+
+	s.mux.HandleFunc("GET /{$}", s.landing)
+	s.mux.HandleFunc("GET /conformance", s.conformance)
+	s.mux.HandleFunc("GET /collections", s.listCollections)
+	s.mux.HandleFunc("GET /collections/{collectionId}", s.getCollection)
+	s.mux.HandleFunc("GET /collections/{collectionId}/items", s.listItems)
+	s.mux.HandleFunc("GET /collections/{collectionId}/items/{featureId}", s.getItem)
+
+	return s, nil
+}
+
+// ServeHTTP impl http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type (
+	link struct {
+		Href  string `json:"href"`
+		Rel   string `json:"rel"`
+		Type  string `json:"type,omitempty"`
+		Title string `json:"title,omitempty"`
+	}
+
+	landingPage struct {
+		Title       string `json:"title,omitempty"`
+		Description string `json:"description,omitempty"`
+		Links       []link `json:"links"`
+	}
+
+	conformance struct {
+		ConformsTo []string `json:"conformsTo"`
+	}
+
+	collectionInfo struct {
+		ID          string   `json:"id"`
+		Title       string   `json:"title,omitempty"`
+		Description string   `json:"description,omitempty"`
+		ItemType    string   `json:"itemType"`
+		CRS         []string `json:"crs"`
+		Links       []link   `json:"links"`
+	}
+
+	collections struct {
+		Collections []collectionInfo `json:"collections"`
+		Links       []link           `json:"links"`
+	}
+
+	feature struct {
+		Type       string         `json:"type"`
+		ID         any            `json:"id"`
+		Geometry   any            `json:"geometry"`
+		Properties map[string]any `json:"properties"`
+		Links      []link         `json:"links,omitempty"`
+	}
+
+	featureCollection struct {
+		Type           string    `json:"type"`
+		Features       []feature `json:"features"`
+		NumberMatched  int64     `json:"numberMatched"`
+		NumberReturned int       `json:"numberReturned"`
+		Links          []link    `json:"links"`
+	}
+
+	exception struct {
+		Code        string `json:"code"`
+		Description string `json:"description,omitempty"`
+	}
+)
+
+func (s *Server) landing(w http.ResponseWriter, r *http.Request) {
+	base := s.baseURL(r)
+
+	writeJSON(w, http.StatusOK, ContentTypeJSON, landingPage{
+		Title:       s.config.Title,
+		Description: s.config.Description,
+		Links: []link{
+			{Href: base + "/", Rel: "self", Type: ContentTypeJSON, Title: "this document"},
+			{Href: base + "/conformance", Rel: "conformance", Type: ContentTypeJSON, Title: "conformance classes"},
+			{Href: base + "/collections", Rel: "data", Type: ContentTypeJSON, Title: "feature collections"},
+		},
+	})
+}
+
+func (s *Server) conformance(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, ContentTypeJSON, conformance{ConformsTo: ConformanceClasses})
+}
+
+func (s *Server) listCollections(w http.ResponseWriter, r *http.Request) {
+	base := s.baseURL(r)
+
+	result := collections{
+		Collections: make([]collectionInfo, 0, len(s.collections)),
+		Links:       []link{{Href: base + "/collections", Rel: "self", Type: ContentTypeJSON}},
+	}
+
+	for _, c := range s.collections {
+		result.Collections = append(result.Collections, s.collectionInfo(base, c))
+	}
+
+	writeJSON(w, http.StatusOK, ContentTypeJSON, result)
+}
+
+func (s *Server) getCollection(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.collection(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ContentTypeJSON, s.collectionInfo(s.baseURL(r), c))
+}
+
+func (s *Server) listItems(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.collection(w, r)
+	if !ok {
+		return
+	}
+
+	params, err := parseItemsParams(r.URL.Query(), s.config)
+	if err != nil {
+		writeException(w, http.StatusBadRequest, "InvalidParameterValue", err.Error())
+		return
+	}
+
+	db := s.db.WithContext(r.Context())
+
+	query, err := c.query(db, params)
+	if err != nil {
+		writeException(w, http.StatusBadRequest, "InvalidParameterValue", err.Error())
+		return
+	}
+
+	var matched int64
+	if err = query.Session(&gorm.Session{}).Count(&matched).Error; err != nil {
+		writeException(w, http.StatusInternalServerError, "ServerError", "")
+		return
+	}
+
+	items := c.newSlice()
+
+	err = query.
+		Order(c.column(c.primary)).
+		Limit(params.Limit).
+		Offset(params.Offset).
+		Find(items.Interface()).Error
+	if err != nil {
+		writeException(w, http.StatusInternalServerError, "ServerError", "")
+		return
+	}
+
+	var (
+		base   = s.baseURL(r)
+		values = items.Elem()
+
+		result = featureCollection{
+			Type:           "FeatureCollection",
+			Features:       make([]feature, 0, values.Len()),
+			NumberMatched:  matched,
+			NumberReturned: values.Len(),
+		}
+	)
+
+	for i := 0; i < values.Len(); i++ {
+		result.Features = append(result.Features, c.feature(r.Context(), values.Index(i)))
+	}
+
+	itemsURL := base + "/collections/" + url.PathEscape(c.ID) + "/items"
+
+	result.Links = append(result.Links, link{
+		Href: pageURL(itemsURL, r.URL.Query(), params.Limit, params.Offset), Rel: "self", Type: ContentTypeGeoJSON,
+	})
+
+	if next := params.Offset + params.Limit; int64(next) < matched {
+		result.Links = append(result.Links, link{
+			Href: pageURL(itemsURL, r.URL.Query(), params.Limit, next), Rel: "next", Type: ContentTypeGeoJSON,
+		})
+	}
+
+	if params.Offset > 0 {
+		result.Links = append(result.Links, link{
+			Href: pageURL(itemsURL, r.URL.Query(), params.Limit, max(params.Offset-params.Limit, 0)), Rel: "prev", Type: ContentTypeGeoJSON,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, ContentTypeGeoJSON, result)
+}
+
+func (s *Server) getItem(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.collection(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := c.parseID(r.PathValue("featureId"))
+	if !ok {
+		writeException(w, http.StatusNotFound, "NotFound", "feature not found")
+		return
+	}
+
+	item := reflect.New(c.schema.ModelType)
+
+	err := s.db.WithContext(r.Context()).
+		Model(c.Model).
+		Scopes(c.Scopes...).
+		Where("? = ?", c.column(c.primary), id).
+		First(item.Interface()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeException(w, http.StatusNotFound, "NotFound", "feature not found")
+			return
+		}
+
+		writeException(w, http.StatusInternalServerError, "ServerError", "")
+		return
+	}
+
+	var (
+		base          = s.baseURL(r)
+		collectionURL = base + "/collections/" + url.PathEscape(c.ID)
+		result        = c.feature(r.Context(), item.Elem())
+	)
+
+	result.Links = []link{
+		{Href: collectionURL + "/items/" + url.PathEscape(r.PathValue("featureId")), Rel: "self", Type: ContentTypeGeoJSON},
+		{Href: collectionURL, Rel: "collection", Type: ContentTypeJSON},
+	}
+
+	writeJSON(w, http.StatusOK, ContentTypeGeoJSON, result)
+}
+
+// collection finds collection of the request or writes not found exception
+func (s *Server) collection(w http.ResponseWriter, r *http.Request) (*collection, bool) {
+	c, ok := s.byID[r.PathValue("collectionId")]
+	if !ok {
+		writeException(w, http.StatusNotFound, "NotFound", "collection not found")
+	}
+
+	return c, ok
+}
+
+func (s *Server) collectionInfo(base string, c *collection) collectionInfo {
+	collectionURL := base + "/collections/" + url.PathEscape(c.ID)
+
+	return collectionInfo{
+		ID:          c.ID,
+		Title:       c.Title,
+		Description: c.Description,
+		ItemType:    "feature",
+		CRS:         []string{CRS84},
+		Links: []link{
+			{Href: collectionURL, Rel: "self", Type: ContentTypeJSON},
+			{Href: collectionURL + "/items", Rel: "items", Type: ContentTypeGeoJSON},
+		},
+	}
+}
+
+func (s *Server) baseURL(r *http.Request) string {
+	if s.config.BaseURL != "" {
+		return s.config.BaseURL
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	return scheme + "://" + r.Host
+}
+
+func pageURL(itemsURL string, query url.Values, limit, offset int) string {
+	page := url.Values{}
+	for key, values := range query {
+		page[key] = values
+	}
+
+	page.Set("limit", strconv.Itoa(limit))
+	page.Set("offset", strconv.Itoa(offset))
+
+	return itemsURL + "?" + page.Encode()
+}
+
+func writeJSON(w http.ResponseWriter, status int, contentType string, body any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeException(w http.ResponseWriter, status int, code, description string) {
+	if description == "" {
+		description = http.StatusText(status)
+	}
+
+	writeJSON(w, status, ContentTypeJSON, exception{Code: code, Description: description})
+}
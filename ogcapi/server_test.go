@@ -0,0 +1,166 @@
+package ogcapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/ybru-tech/georm"
+)
+
+type Zone struct {
+	ID         uint `gorm:"primaryKey"`
+	Title      string
+	ValidFrom  time.Time
+	GeoPolygon georm.Polygon
+}
+
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestNewServer(t *testing.T) {
+	db := dryRunDB(t)
+
+	server, err := NewServer(db, Config{}, Collection{Model: &Zone{}})
+	require.NoError(t, err)
+
+	c := server.byID["zones"]
+	require.NotNil(t, c)
+	assert.Equal(t, "geo_polygon", c.geometry.DBName)
+	assert.Equal(t, "id", c.primary.DBName)
+	assert.Len(t, c.properties, 2) // title, valid_from
+
+	_, err = NewServer(db, Config{}, Collection{Model: &Zone{}}, Collection{Model: &Zone{}})
+	assert.ErrorIs(t, err, ErrDuplicateCollection)
+
+	_, err = NewServer(db, Config{}, Collection{Model: &struct{ ID uint }{}})
+	assert.ErrorIs(t, err, ErrNoGeometryField)
+
+	_, err = NewServer(db, Config{}, Collection{Model: &Zone{}, Datetime: "unknown"})
+	assert.ErrorIs(t, err, ErrFieldNotFound)
+}
+
+func TestCollectionQuery(t *testing.T) {
+	db := dryRunDB(t)
+
+	c, err := newCollection(db, Collection{Model: &Zone{}, Datetime: "ValidFrom"})
+	require.NoError(t, err)
+
+	start := time.Date(2018, 2, 12, 0, 0, 0, 0, time.UTC)
+
+	actual := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		query, err := c.query(tx, ItemsParams{
+			BBox:     &BBox{MinX: 1, MinY: 2, MaxX: 3, MaxY: 4},
+			Datetime: &Interval{Start: &start},
+		})
+		require.NoError(t, err)
+
+		return query.Find(&[]Zone{})
+	})
+
+	expect := `SELECT * FROM "zones" WHERE "zones"."geo_polygon" && ST_MakeEnvelope(1, 2, 3, 4, 4326) ` +
+		`AND "zones"."valid_from" >= '2018-02-12 00:00:00'`
+
+	assert.Equal(t, expect, actual)
+
+	c, err = newCollection(db, Collection{Model: &Zone{}})
+	require.NoError(t, err)
+
+	_, err = c.query(db, ItemsParams{Datetime: &Interval{Start: &start}})
+	assert.ErrorIs(t, err, ErrDatetimeUnsupported)
+}
+
+func TestServerMetadata(t *testing.T) {
+	server, err := NewServer(dryRunDB(t), Config{Title: "zones", BaseURL: "https://example.com/ogc/"},
+		Collection{Model: &Zone{}, ID: "zone", Title: "Zones"},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		Path   string
+		Status int
+		Expect string
+	}{
+		{
+			Path:   "/",
+			Status: http.StatusOK,
+			Expect: `{"title":"zones","links":[
+				{"href":"https://example.com/ogc/","rel":"self","type":"application/json","title":"this document"},
+				{"href":"https://example.com/ogc/conformance","rel":"conformance","type":"application/json","title":"conformance classes"},
+				{"href":"https://example.com/ogc/collections","rel":"data","type":"application/json","title":"feature collections"}
+			]}`,
+		},
+		{
+			Path:   "/conformance",
+			Status: http.StatusOK,
+			Expect: `{"conformsTo":[
+				"http://www.opengis.net/spec/ogcapi-features-1/1.0/conf/core",
+				"http://www.opengis.net/spec/ogcapi-features-1/1.0/conf/geojson"
+			]}`,
+		},
+		{
+			Path:   "/collections/zone",
+			Status: http.StatusOK,
+			Expect: `{"id":"zone","title":"Zones","itemType":"feature","crs":["http://www.opengis.net/def/crs/OGC/1.3/CRS84"],"links":[
+				{"href":"https://example.com/ogc/collections/zone","rel":"self","type":"application/json"},
+				{"href":"https://example.com/ogc/collections/zone/items","rel":"items","type":"application/geo+json"}
+			]}`,
+		},
+		{
+			Path:   "/collections/unknown",
+			Status: http.StatusNotFound,
+			Expect: `{"code":"NotFound","description":"collection not found"}`,
+		},
+		{
+			Path:   "/collections/unknown/items",
+			Status: http.StatusNotFound,
+			Expect: `{"code":"NotFound","description":"collection not found"}`,
+		},
+		{
+			Path:   "/collections/zone/items?limit=-1",
+			Status: http.StatusBadRequest,
+			Expect: `{"code":"InvalidParameterValue","description":"invalid parameter: limit \"-1\""}`,
+		},
+		{
+			Path:   "/collections/zone/items/abc",
+			Status: http.StatusNotFound,
+			Expect: `{"code":"NotFound","description":"feature not found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.Path, nil))
+
+			assert.Equal(t, test.Status, recorder.Code)
+			assert.JSONEq(t, test.Expect, recorder.Body.String())
+		})
+	}
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/collections", nil))
+
+	var list collections
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&list))
+	require.Len(t, list.Collections, 1)
+	assert.Equal(t, "zone", list.Collections[0].ID)
+}
@@ -0,0 +1,96 @@
+package georm
+
+import (
+	"slices"
+
+	"github.com/twpayne/go-geom"
+)
+
+// Orientation is a winding order of polygon rings
+type Orientation int
+
+const (
+	// OrientationAny keeps rings as they are
+	OrientationAny Orientation = iota
+
+	// OrientationCCW is counter-clockwise exterior and clockwise interior rings,
+	// as required by GeoJSON (RFC 7946 §3.1.6)
+	OrientationCCW
+
+	// OrientationRHR is clockwise exterior and counter-clockwise interior rings,
+	// the right-hand rule of PostGIS ST_ForceRHR
+	OrientationRHR
+)
+
+var (
+	// OrientationOnWrite is the ring orientation of polygons in Value
+	OrientationOnWrite = OrientationAny
+
+	// OrientationInGeoJSON is the ring orientation of polygons in MarshalJSON
+	OrientationInGeoJSON = OrientationAny
+)
+
+// ForceCCW orients exterior rings of polygons counter-clockwise and interior
+// rings clockwise as PostGIS ST_ForcePolygonCCW, other geometries are returned as is
+func (g Geometry[T]) ForceCCW() Geometry[T] {
+	return g.orient(OrientationCCW)
+}
+
+// ForceRHR orients exterior rings of polygons clockwise and interior rings
+// counter-clockwise as PostGIS ST_ForceRHR, other geometries are returned as is
+func (g Geometry[T]) ForceRHR() Geometry[T] {
+	return g.orient(OrientationRHR)
+}
+
+func (g Geometry[T]) orient(orientation Orientation) Geometry[T] {
+	if isNil(g.Geom) || orientation == OrientationAny {
+		return g
+	}
+
+	oriented, ok := orient(g.Geom, orientation == OrientationCCW).(T)
+	if !ok {
+		return g
+	}
+
+	return Geometry[T]{oriented}
+}
+
+func orient(g geom.T, ccw bool) geom.T {
+	switch g := g.(type) {
+	case *geom.Polygon:
+		flatCoords := slices.Clone(g.FlatCoords())
+		orientRings(flatCoords, g.Stride(), 0, g.Ends(), ccw)
+
+		return geom.NewPolygonFlat(g.Layout(), flatCoords, g.Ends()).SetSRID(g.SRID())
+	case *geom.MultiPolygon:
+		flatCoords, offset := slices.Clone(g.FlatCoords()), 0
+		for _, ends := range g.Endss() {
+			offset = orientRings(flatCoords, g.Stride(), offset, ends, ccw)
+		}
+
+		return geom.NewMultiPolygonFlat(g.Layout(), flatCoords, g.Endss()).SetSRID(g.SRID())
+	case *geom.GeometryCollection:
+		gc := geom.NewGeometryCollection().SetSRID(g.SRID())
+
+		for _, child := range g.Geoms() {
+			_ = gc.Push(orient(child, ccw))
+		}
+
+		return gc
+	default:
+		return g
+	}
+}
+
+// orientRings orients rings of a polygon starting at offset in place,
+// the first ring is exterior. The end of the polygon is returned
+func orientRings(flatCoords []float64, stride, offset int, ends []int, ccw bool) int {
+	for i, end := range ends {
+		ring := flatCoords[offset:end]
+		copy(ring, orientRing(ring, stride, ccw == (i == 0)))
+
+		offset = end
+	}
+
+	return offset
+}
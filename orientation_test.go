@@ -0,0 +1,116 @@
+package georm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestGeometryForceCCW(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Geom   geom.T
+		Expect geom.T
+	}{
+		{
+			Name:   "nil",
+			Geom:   nil,
+			Expect: nil,
+		},
+		{
+			Name:   "point is returned as is",
+			Geom:   geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{42, 42}),
+			Expect: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{42, 42}),
+		},
+		{
+			Name: "polygon with hole",
+			Geom: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}},
+				{{2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}},
+			}).SetSRID(SRID),
+			Expect: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+				{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}},
+			}).SetSRID(SRID),
+		},
+		{
+			Name: "oriented polygon",
+			Geom: geom.NewPolygon(geom.XYZ).MustSetCoords([][]geom.Coord{
+				{{0, 0, 1}, {10, 0, 2}, {10, 10, 3}, {0, 0, 1}},
+			}),
+			Expect: geom.NewPolygon(geom.XYZ).MustSetCoords([][]geom.Coord{
+				{{0, 0, 1}, {10, 0, 2}, {10, 10, 3}, {0, 0, 1}},
+			}),
+		},
+		{
+			Name: "multipolygon",
+			Geom: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+				{{{5, 5}, {5, 6}, {6, 6}, {5, 5}}},
+			}),
+			Expect: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+				{{{5, 5}, {6, 6}, {5, 6}, {5, 5}}},
+			}),
+		},
+		{
+			Name: "collection",
+			Geom: geom.NewGeometryCollection().MustPush(
+				geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{42, 42}),
+				geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 1}, {1, 0}, {0, 0}}}),
+			),
+			Expect: geom.NewGeometryCollection().MustPush(
+				geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{42, 42}),
+				geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expect, New(test.Geom).ForceCCW().Geom)
+		})
+	}
+}
+
+func TestGeometryForceRHR(t *testing.T) {
+	polygon := NewPolygon(
+		[]geom.Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		[]geom.Coord{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}},
+	)
+
+	rhr := polygon.ForceRHR()
+	assert.Equal(t, [][]geom.Coord{
+		{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}},
+		{{2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}},
+	}, rhr.Geom.Coords())
+	assert.Equal(t, SRID, rhr.Geom.SRID())
+
+	// the source is not modified
+	assert.Equal(t, polygon.Geom.Coords(), rhr.ForceCCW().Geom.Coords())
+	assert.Equal(t, [][]geom.Coord{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}},
+	}, polygon.Geom.Coords())
+}
+
+func TestOrientationOnWrite(t *testing.T) {
+	OrientationOnWrite, OrientationInGeoJSON = OrientationRHR, OrientationCCW
+	defer func() { OrientationOnWrite, OrientationInGeoJSON = OrientationAny, OrientationAny }()
+
+	zone := NewPolygon([]geom.Coord{{0, 0}, {10, 0}, {10, 10}, {0, 0}})
+
+	value, err := zone.Value()
+	require.NoError(t, err)
+
+	var scanned Polygon
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, [][]geom.Coord{{{0, 0}, {10, 10}, {10, 0}, {0, 0}}}, scanned.Geom.Coords())
+
+	data, err := json.Marshal(scanned)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,0]]]}`, string(data))
+}
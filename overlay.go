@@ -0,0 +1,548 @@
+package georm
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/xy"
+	"github.com/twpayne/go-geom/xy/location"
+)
+
+var ErrMixedSRID = errors.New("operation on mixed SRID geometries")
+
+// Intersection returns the area covered by both Polygon or MultiPolygon
+// geometries as PostGIS ST_Intersection, lines and points where geometries
+// only touch are not returned
+func (g Geometry[T]) Intersection(other Geometric) (Geometry[T], error) {
+	return g.overlay(other, func(inA, inB bool) bool { return inA && inB })
+}
+
+// Union returns the area covered by any of Polygon or MultiPolygon geometries
+// as PostGIS ST_Union
+func (g Geometry[T]) Union(other Geometric) (Geometry[T], error) {
+	return g.overlay(other, func(inA, inB bool) bool { return inA || inB })
+}
+
+// Difference returns the area of geometry not covered by the other one
+// as PostGIS ST_Difference
+func (g Geometry[T]) Difference(other Geometric) (Geometry[T], error) {
+	return g.overlay(other, func(inA, inB bool) bool { return inA && !inB })
+}
+
+// SymDifference returns the area covered by only one of geometries
+// as PostGIS ST_SymDifference
+func (g Geometry[T]) SymDifference(other Geometric) (Geometry[T], error) {
+	return g.overlay(other, func(inA, inB bool) bool { return inA != inB })
+}
+
+// overlay applies overlay operation to Polygon or MultiPolygon geometries.
+// The result is XY with the SRID of geometry, Z and M are dropped. Result
+// of several polygons can only be returned as Geometry[geom.T] or MultiPolygon
+func (g Geometry[T]) overlay(other Geometric, include func(inA, inB bool) bool) (Geometry[T], error) {
+	var otherT geom.T
+	if other != nil {
+		otherT = other.GeomT()
+	}
+
+	a, err := overlayOperand(g.Geom)
+	if err != nil {
+		return g, err
+	}
+
+	b, err := overlayOperand(otherT)
+	if err != nil {
+		return g, err
+	}
+
+	var srid int
+
+	switch {
+	case !isNil(g.Geom) && !isNil(otherT) && g.Geom.SRID() != otherT.SRID():
+		return g, fmt.Errorf("%w: %d and %d", ErrMixedSRID, g.Geom.SRID(), otherT.SRID())
+	case !isNil(g.Geom):
+		srid = g.Geom.SRID()
+	case !isNil(otherT):
+		srid = otherT.SRID()
+	}
+
+	polygons := overlayPolygons(a, b, include)
+
+	var result geom.T
+
+	switch _, multi := any(g.Geom).(*geom.MultiPolygon); {
+	case multi || len(polygons) > 1:
+		result = newMultiPolygon(geom.XY, srid, polygons)
+	case len(polygons) == 1:
+		result = polygons[0].SetSRID(srid)
+	default:
+		result = geom.NewPolygon(geom.XY).SetSRID(srid)
+	}
+
+	typed, ok := result.(T)
+	if !ok {
+		return g, fmt.Errorf("%w: result is %T", ErrUnexpectedGeometryType, result)
+	}
+
+	return Geometry[T]{typed}, nil
+}
+
+func overlayOperand(g geom.T) ([]*geom.Polygon, error) {
+	if isNil(g) {
+		return nil, nil
+	}
+
+	switch g := g.(type) {
+	case *geom.Polygon:
+		return []*geom.Polygon{g}, nil
+	case *geom.MultiPolygon:
+		polygons := make([]*geom.Polygon, g.NumPolygons())
+		for i := range polygons {
+			polygons[i] = g.Polygon(i)
+		}
+
+		return polygons, nil
+	default:
+		return nil, fmt.Errorf("%w: overlay of %T", ErrUnexpectedGeometryType, g)
+	}
+}
+
+// overlayPolygons returns polygons of the plane covered according to include,
+// which is called with coverage of the area by polygons of operands a and b
+func overlayPolygons(a, b []*geom.Polygon, include func(inA, inB bool) bool) []*geom.Polygon {
+	return overlayWindings(a, b, func(winding [2]int) bool { return include(winding[0] > 0, winding[1] > 0) })
+}
+
+// overlayWindings returns polygons of the plane covered according to include,
+// which is called with winding numbers of the area by rings of operands a and b.
+// Rings of polygons are noded with each other into a planar graph, faces of
+// the graph get winding numbers of operands and boundaries between included
+// and excluded faces become rings of the result. The result is XY
+func overlayWindings(a, b []*geom.Polygon, include func(winding [2]int) bool) []*geom.Polygon {
+	graph := &overlayGraph{vertices: map[[2]float64]int{}}
+
+	var srid int
+
+	for operand, polygons := range [2][]*geom.Polygon{a, b} {
+		for _, p := range polygons {
+			if srid == 0 {
+				srid = p.SRID()
+			}
+
+			for i := 0; i < p.NumLinearRings(); i++ {
+				ring := dedupeRing(xyCoords(p.LinearRing(i).FlatCoords(), p.Stride()), 2)
+				if len(ring) < 4*2 {
+					continue
+				}
+
+				graph.addRing(orientRing(ring, 2, i == 0), operand)
+			}
+		}
+	}
+
+	graph.build()
+	graph.traceCycles()
+	graph.windCycles()
+
+	inside := make([]bool, len(graph.cycleArea))
+	for c, winding := range graph.cycleWinding {
+		inside[c] = include(winding)
+	}
+
+	var shells, holes [][]float64
+
+	for _, ring := range graph.boundaryRings(func(h int) bool {
+		return inside[graph.cycle[h]] && !inside[graph.cycle[h^1]]
+	}) {
+		if area := ringArea(ring, 2); area > 0 {
+			shells = append(shells, ring)
+		} else if area < 0 {
+			holes = append(holes, ring)
+		}
+	}
+
+	return assemblePolygons(geom.XY, srid, shells, holes)
+}
+
+// overlayGraph is a planar graph of noded rings, half-edges 2e and 2e+1
+// are twins of edge e
+type overlayGraph struct {
+	rings    [][]float64
+	operands []int
+
+	vertices map[[2]float64]int
+	coords   [][2]float64
+	origin   []int
+
+	// count of input ring segments along the half-edge by operands,
+	// the face on the left of half-edge is inside the ring
+	count [][2]int
+
+	// outgoing half-edges of vertices sorted by angle and positions in them
+	outgoing [][]int
+	position []int
+
+	next  []int
+	cycle []int
+
+	cycleArea    []float64
+	cycleWinding [][2]int
+}
+
+func (g *overlayGraph) addRing(ring []float64, operand int) {
+	g.rings = append(g.rings, ring)
+	g.operands = append(g.operands, operand)
+}
+
+func (g *overlayGraph) vertex(c geom.Coord) int {
+	key := [2]float64{c[0], c[1]}
+
+	v, ok := g.vertices[key]
+	if !ok {
+		v = len(g.coords)
+		g.vertices[key] = v
+		g.coords = append(g.coords, key)
+		g.outgoing = append(g.outgoing, nil)
+	}
+
+	return v
+}
+
+// build nodes segments of rings and adds their parts as edges of the graph
+func (g *overlayGraph) build() {
+	var (
+		segments []segment
+		owners   []int
+	)
+
+	for r, ring := range g.rings {
+		for _, s := range ringSegments(ring, 2) {
+			segments = append(segments, s)
+			owners = append(owners, r)
+		}
+	}
+
+	nodes := make([][]geom.Coord, len(segments))
+
+	addNode := func(i int, c geom.Coord) {
+		s := segments[i]
+		if c.Equal(geom.XY, s.start) || c.Equal(geom.XY, s.end) {
+			return
+		}
+
+		nodes[i] = append(nodes[i], c)
+	}
+
+	segmentPairs(segments, nil, func(i, j int) bool {
+		r := intersect(segments[i], segments[j])
+
+		switch r.kind {
+		case pointIntersection:
+			addNode(i, r.at)
+			addNode(j, r.at)
+		case collinearIntersection:
+			for _, c := range []geom.Coord{segments[i].start, segments[i].end} {
+				if onSegment(segments[j], c) {
+					addNode(j, c)
+				}
+			}
+
+			for _, c := range []geom.Coord{segments[j].start, segments[j].end} {
+				if onSegment(segments[i], c) {
+					addNode(i, c)
+				}
+			}
+		}
+
+		return true
+	})
+
+	edges := map[[2]int]int{}
+
+	for i, s := range segments {
+		slices.SortFunc(nodes[i], func(c1, c2 geom.Coord) int {
+			return cmp.Compare(distance2(s.start, c1), distance2(s.start, c2))
+		})
+
+		points := append(append([]geom.Coord{s.start}, nodes[i]...), s.end)
+		operand := g.operands[owners[i]]
+
+		for j := 1; j < len(points); j++ {
+			u, v := g.vertex(points[j-1]), g.vertex(points[j])
+			if u == v {
+				continue
+			}
+
+			key, h := [2]int{u, v}, 0
+			if u > v {
+				key, h = [2]int{v, u}, 1
+			}
+
+			e, ok := edges[key]
+			if !ok {
+				e = len(g.origin) / 2
+				edges[key] = e
+
+				g.origin = append(g.origin, key[0], key[1])
+				g.count = append(g.count, [2]int{}, [2]int{})
+			}
+
+			g.count[2*e+h][operand]++
+		}
+	}
+
+	g.position = make([]int, len(g.origin))
+
+	for h, v := range g.origin {
+		g.outgoing[v] = append(g.outgoing[v], h)
+	}
+
+	for v, hs := range g.outgoing {
+		slices.SortFunc(hs, func(h1, h2 int) int { return cmp.Compare(g.angle(h1), g.angle(h2)) })
+
+		for i, h := range hs {
+			g.position[h] = i
+		}
+
+		g.outgoing[v] = hs
+	}
+}
+
+func (g *overlayGraph) angle(h int) float64 {
+	start, end := g.coords[g.origin[h]], g.coords[g.origin[h^1]]
+	return math.Atan2(end[1]-start[1], end[0]-start[0])
+}
+
+// turn returns the outgoing half-edge of the end of h following the twin of h clockwise
+func (g *overlayGraph) turn(h int) int {
+	twin := h ^ 1
+	hs := g.outgoing[g.origin[twin]]
+
+	return hs[(g.position[twin]+len(hs)-1)%len(hs)]
+}
+
+// traceCycles links half-edges into cycles with the face on the left
+func (g *overlayGraph) traceCycles() {
+	g.next = make([]int, len(g.origin))
+	g.cycle = make([]int, len(g.origin))
+
+	for h := range g.origin {
+		g.next[h] = g.turn(h)
+		g.cycle[h] = -1
+	}
+
+	for h := range g.origin {
+		if g.cycle[h] != -1 {
+			continue
+		}
+
+		c := len(g.cycleArea)
+
+		var area float64
+
+		for e := h; g.cycle[e] == -1; e = g.next[e] {
+			g.cycle[e] = c
+
+			start, end := g.coords[g.origin[e]], g.coords[g.origin[e^1]]
+			area += start[0]*end[1] - end[0]*start[1]
+		}
+
+		g.cycleArea = append(g.cycleArea, area/2)
+	}
+}
+
+// windCycles finds winding numbers of operands for faces of cycles. Outer
+// cycle of every connected component is wound by rings of other components,
+// windings spread to other cycles across edges
+func (g *overlayGraph) windCycles() {
+	var (
+		parent    = make([]int, len(g.coords))
+		component func(v int) int
+	)
+
+	for v := range parent {
+		parent[v] = v
+	}
+
+	component = func(v int) int {
+		for parent[v] != v {
+			parent[v] = parent[parent[v]]
+			v = parent[v]
+		}
+
+		return v
+	}
+
+	for h := 0; h < len(g.origin); h += 2 {
+		parent[component(g.origin[h])] = component(g.origin[h+1])
+	}
+
+	// outer cycle of a component has the least signed area
+	outer := map[int]int{}
+
+	for h, c := range g.cycle {
+		root := component(g.origin[h])
+		if o, ok := outer[root]; !ok || g.cycleArea[c] < g.cycleArea[o] {
+			outer[root] = c
+		}
+	}
+
+	ringComponents := make([]int, len(g.rings))
+	for r, ring := range g.rings {
+		ringComponents[r] = component(g.vertices[[2]float64{ring[0], ring[1]}])
+	}
+
+	g.cycleWinding = make([][2]int, len(g.cycleArea))
+	wound := make([]bool, len(g.cycleArea))
+
+	var queue []int
+
+	for h, c := range g.cycle {
+		root := component(g.origin[h])
+		if outer[root] != c || wound[c] {
+			continue
+		}
+
+		p := g.coords[g.origin[h]]
+
+		for r, ring := range g.rings {
+			if ringComponents[r] == root || xy.LocatePointInRing(geom.XY, p[:], ring) != location.Interior {
+				continue
+			}
+
+			if ringArea(ring, 2) > 0 {
+				g.cycleWinding[c][g.operands[r]]++
+			} else {
+				g.cycleWinding[c][g.operands[r]]--
+			}
+		}
+
+		wound[c] = true
+		queue = append(queue, c)
+	}
+
+	halfEdges := make([][]int, len(g.cycleArea))
+	for h, c := range g.cycle {
+		halfEdges[c] = append(halfEdges[c], h)
+	}
+
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+
+		for _, h := range halfEdges[c] {
+			d := g.cycle[h^1]
+			if wound[d] {
+				continue
+			}
+
+			for operand := range g.cycleWinding[d] {
+				g.cycleWinding[d][operand] = g.cycleWinding[c][operand] - g.count[h][operand] + g.count[h^1][operand]
+			}
+
+			wound[d] = true
+			queue = append(queue, d)
+		}
+	}
+}
+
+// boundaryRings traces selected half-edges into closed simple rings with
+// the selected side on the left, collinear vertices are removed
+func (g *overlayGraph) boundaryRings(selected func(h int) bool) [][]float64 {
+	var (
+		rings [][]float64
+		used  = make([]bool, len(g.origin))
+	)
+
+	for h := range g.origin {
+		if used[h] || !selected(h) {
+			continue
+		}
+
+		var ring []float64
+
+		for e := h; !used[e]; {
+			used[e] = true
+			ring = append(ring, g.coords[g.origin[e]][:]...)
+
+			// the next selected half-edge clockwise from the twin
+			next := g.turn(e)
+			for !selected(next) {
+				next = g.turn(next ^ 1)
+			}
+
+			e = next
+		}
+
+		// rings touching themselves at a vertex are split into simple loops
+		for _, loop := range simpleLoops(append(ring, ring[0], ring[1]), 2) {
+			if loop = removeCollinear(loop[:len(loop)-2]); len(loop) >= 3*2 {
+				rings = append(rings, append(loop, loop[0], loop[1]))
+			}
+		}
+	}
+
+	return rings
+}
+
+// removeCollinear removes vertices of the unclosed ring lying on the line
+// through their neighbours
+func removeCollinear(ring []float64) []float64 {
+	for removed := true; removed && len(ring) >= 3*2; {
+		removed = false
+		n := len(ring) / 2
+
+		for i := 0; i < n && n >= 3; i++ {
+			prev, next := (i+n-1)%n, (i+1)%n
+
+			a := geom.Coord{ring[2*prev], ring[2*prev+1]}
+			b := geom.Coord{ring[2*i], ring[2*i+1]}
+			c := geom.Coord{ring[2*next], ring[2*next+1]}
+
+			if orientation(a, b, c) == 0 {
+				ring = slices.Delete(ring, 2*i, 2*i+2)
+				n--
+				removed = true
+			}
+		}
+	}
+
+	return ring
+}
+
+// assemblePolygons makes polygons of counter-clockwise shells and clockwise holes,
+// every hole belongs to the smallest shell containing it, holes outside shells are dropped
+func assemblePolygons(layout geom.Layout, srid int, shells, holes [][]float64) []*geom.Polygon {
+	stride := layout.Stride()
+
+	polygons := make([]*geom.Polygon, len(shells))
+	for i, shell := range shells {
+		polygons[i] = geom.NewPolygonFlat(layout, shell, []int{len(shell)}).SetSRID(srid)
+	}
+
+	for _, hole := range holes {
+		var (
+			owner = -1
+			area  float64
+		)
+
+		for i, shell := range shells {
+			if !ringInside(layout, hole, shell) {
+				continue
+			}
+
+			if shellArea := math.Abs(ringArea(shell, stride)); owner == -1 || shellArea < area {
+				owner, area = i, shellArea
+			}
+		}
+
+		if owner != -1 {
+			_ = polygons[owner].Push(geom.NewLinearRingFlat(layout, hole))
+		}
+	}
+
+	return polygons
+}
@@ -0,0 +1,234 @@
+package georm
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func mustWKT(t *testing.T, text string) Geometry[geom.T] {
+	t.Helper()
+
+	var g Geometry[geom.T]
+	require.NoError(t, g.UnmarshalWKT(text, AxisXY))
+
+	return g
+}
+
+// assertSameArea checks that geometries cover the same area,
+// overlay results differ from PostGIS in vertex order and collinear vertices
+func assertSameArea(t *testing.T, expect, actual Geometry[geom.T]) {
+	t.Helper()
+
+	assert.InDelta(t, expect.Area(), actual.Area(), 1e-9)
+
+	difference, err := expect.SymDifference(actual)
+	require.NoError(t, err)
+	assert.InDelta(t, 0, difference.Area(), 1e-9, actual.String())
+
+	assert.True(t, actual.IsValid(), actual.String())
+}
+
+func TestGeometryOverlay(t *testing.T) {
+	// expected results of PostGIS ST_Intersection, ST_Union, ST_Difference
+	// and ST_SymDifference, lower dimension intersections are empty
+	tests := []struct {
+		Name          string
+		A, B          string
+		Intersection  string
+		Union         string
+		Difference    string
+		SymDifference string
+	}{
+		{
+			Name:          "overlapping squares",
+			A:             "POLYGON((0 0,2 0,2 2,0 2,0 0))",
+			B:             "POLYGON((1 1,3 1,3 3,1 3,1 1))",
+			Intersection:  "POLYGON((1 2,2 2,2 1,1 1,1 2))",
+			Union:         "POLYGON((0 0,0 2,1 2,1 3,3 3,3 1,2 1,2 0,0 0))",
+			Difference:    "POLYGON((0 0,0 2,1 2,1 1,2 1,2 0,0 0))",
+			SymDifference: "MULTIPOLYGON(((0 0,0 2,1 2,1 1,2 1,2 0,0 0)),((2 1,2 2,1 2,1 3,3 3,3 1,2 1)))",
+		},
+		{
+			Name:          "shared edge",
+			A:             "POLYGON((0 0,1 0,1 1,0 1,0 0))",
+			B:             "POLYGON((1 0,2 0,2 1,1 1,1 0))",
+			Intersection:  "POLYGON EMPTY",
+			Union:         "POLYGON((0 0,0 1,1 1,2 1,2 0,1 0,0 0))",
+			Difference:    "POLYGON((0 0,0 1,1 1,1 0,0 0))",
+			SymDifference: "POLYGON((0 0,0 1,1 1,2 1,2 0,1 0,0 0))",
+		},
+		{
+			Name:          "touching corners",
+			A:             "POLYGON((0 0,1 0,1 1,0 1,0 0))",
+			B:             "POLYGON((1 1,2 1,2 2,1 2,1 1))",
+			Intersection:  "POLYGON EMPTY",
+			Union:         "MULTIPOLYGON(((0 0,0 1,1 1,1 0,0 0)),((1 1,1 2,2 2,2 1,1 1)))",
+			Difference:    "POLYGON((0 0,0 1,1 1,1 0,0 0))",
+			SymDifference: "MULTIPOLYGON(((0 0,0 1,1 1,1 0,0 0)),((1 1,1 2,2 2,2 1,1 1)))",
+		},
+		{
+			Name:          "identical",
+			A:             "POLYGON((0 0,1 0,1 1,0 1,0 0))",
+			B:             "POLYGON((1 1,0 1,0 0,1 0,1 1))",
+			Intersection:  "POLYGON((0 0,0 1,1 1,1 0,0 0))",
+			Union:         "POLYGON((0 0,0 1,1 1,1 0,0 0))",
+			Difference:    "POLYGON EMPTY",
+			SymDifference: "POLYGON EMPTY",
+		},
+		{
+			Name:          "contained",
+			A:             "POLYGON((0 0,10 0,10 10,0 10,0 0))",
+			B:             "POLYGON((2 2,4 2,4 4,2 4,2 2))",
+			Intersection:  "POLYGON((2 2,2 4,4 4,4 2,2 2))",
+			Union:         "POLYGON((0 0,0 10,10 10,10 0,0 0))",
+			Difference:    "POLYGON((0 0,0 10,10 10,10 0,0 0),(2 2,4 2,4 4,2 4,2 2))",
+			SymDifference: "POLYGON((0 0,0 10,10 10,10 0,0 0),(2 2,4 2,4 4,2 4,2 2))",
+		},
+		{
+			Name:          "polygon in hole",
+			A:             "POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,2 8,8 8,8 2,2 2))",
+			B:             "POLYGON((4 4,6 4,6 6,4 6,4 4))",
+			Intersection:  "POLYGON EMPTY",
+			Union:         "MULTIPOLYGON(((0 0,0 10,10 10,10 0,0 0),(2 2,8 2,8 8,2 8,2 2)),((4 4,4 6,6 6,6 4,4 4)))",
+			Difference:    "POLYGON((0 0,0 10,10 10,10 0,0 0),(2 2,8 2,8 8,2 8,2 2))",
+			SymDifference: "MULTIPOLYGON(((0 0,0 10,10 10,10 0,0 0),(2 2,8 2,8 8,2 8,2 2)),((4 4,4 6,6 6,6 4,4 4)))",
+		},
+		{
+			Name:          "polygon covering hole",
+			A:             "POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,2 8,8 8,8 2,2 2))",
+			B:             "POLYGON((1 1,9 1,9 9,1 9,1 1))",
+			Intersection:  "POLYGON((1 1,1 9,9 9,9 1,1 1),(2 2,8 2,8 8,2 8,2 2))",
+			Union:         "POLYGON((0 0,0 10,10 10,10 0,0 0))",
+			Difference:    "POLYGON((0 0,0 10,10 10,10 0,0 0),(1 1,9 1,9 9,1 9,1 1))",
+			SymDifference: "MULTIPOLYGON(((0 0,0 10,10 10,10 0,0 0),(1 1,9 1,9 9,1 9,1 1)),((2 2,2 8,8 8,8 2,2 2)))",
+		},
+		{
+			Name:          "hole touching shell",
+			A:             "POLYGON((0 0,4 0,4 4,0 4,0 0))",
+			B:             "POLYGON((2 0,3 1,2 2,1 1,2 0))",
+			Intersection:  "POLYGON((2 0,1 1,2 2,3 1,2 0))",
+			Union:         "POLYGON((0 0,0 4,4 4,4 0,2 0,0 0))",
+			Difference:    "POLYGON((0 0,0 4,4 4,4 0,2 0,0 0),(2 0,3 1,2 2,1 1,2 0))",
+			SymDifference: "POLYGON((0 0,0 4,4 4,4 0,2 0,0 0),(2 0,3 1,2 2,1 1,2 0))",
+		},
+		{
+			Name:          "crossing edges",
+			A:             "POLYGON((0 0,4 0,2 4,0 0))",
+			B:             "POLYGON((0 2,4 2,4 3,0 3,0 2))",
+			Intersection:  "POLYGON((1 2,1.5 3,2.5 3,3 2,1 2))",
+			Union:         "POLYGON((0 0,1 2,0 2,0 3,1.5 3,2 4,2.5 3,4 3,4 2,3 2,4 0,0 0))",
+			Difference:    "MULTIPOLYGON(((0 0,1 2,3 2,4 0,0 0)),((1.5 3,2 4,2.5 3,1.5 3)))",
+			SymDifference: "MULTIPOLYGON(((0 0,1 2,3 2,4 0,0 0)),((0 2,0 3,1.5 3,1 2,0 2)),((1.5 3,2 4,2.5 3,1.5 3)),((3 2,2.5 3,4 3,4 2,3 2)))",
+		},
+		{
+			Name:          "multi polygon",
+			A:             "MULTIPOLYGON(((0 0,1 0,1 1,0 1,0 0)),((2 0,3 0,3 1,2 1,2 0)))",
+			B:             "POLYGON((0.5 0,2.5 0,2.5 1,0.5 1,0.5 0))",
+			Intersection:  "MULTIPOLYGON(((0.5 0,0.5 1,1 1,1 0,0.5 0)),((2 0,2 1,2.5 1,2.5 0,2 0)))",
+			Union:         "POLYGON((0 0,0 1,3 1,3 0,0 0))",
+			Difference:    "MULTIPOLYGON(((0 0,0 1,0.5 1,0.5 0,0 0)),((2.5 0,2.5 1,3 1,3 0,2.5 0)))",
+			SymDifference: "MULTIPOLYGON(((0 0,0 1,0.5 1,0.5 0,0 0)),((1 0,1 1,2 1,2 0,1 0)),((2.5 0,2.5 1,3 1,3 0,2.5 0)))",
+		},
+		{
+			Name:          "overlapping collinear edges",
+			A:             "POLYGON((0 0,4 0,4 2,0 2,0 0))",
+			B:             "POLYGON((1 0,5 0,5 -2,1 -2,1 0))",
+			Intersection:  "POLYGON EMPTY",
+			Union:         "POLYGON((0 0,0 2,4 2,4 0,5 0,5 -2,1 -2,1 0,0 0))",
+			Difference:    "POLYGON((0 0,0 2,4 2,4 0,0 0))",
+			SymDifference: "POLYGON((0 0,0 2,4 2,4 0,5 0,5 -2,1 -2,1 0,0 0))",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			a, b := mustWKT(t, test.A), mustWKT(t, test.B)
+
+			for _, op := range []struct {
+				Name   string
+				Fn     func(Geometric) (Geometry[geom.T], error)
+				Expect string
+			}{
+				{Name: "intersection", Fn: a.Intersection, Expect: test.Intersection},
+				{Name: "union", Fn: a.Union, Expect: test.Union},
+				{Name: "difference", Fn: a.Difference, Expect: test.Difference},
+				{Name: "sym difference", Fn: a.SymDifference, Expect: test.SymDifference},
+			} {
+				t.Run(op.Name, func(t *testing.T) {
+					result, err := op.Fn(b)
+					require.NoError(t, err)
+
+					assert.Equal(t, SRID, result.Geom.SRID())
+					assertSameArea(t, mustWKT(t, op.Expect), result)
+				})
+			}
+		})
+	}
+}
+
+func TestGeometryOverlayTypes(t *testing.T) {
+	zone := NewPolygon([]geom.Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}})
+	city := NewPolygon([]geom.Coord{{5, -5}, {15, -5}, {15, 5}, {5, 5}, {5, -5}})
+
+	clipped, err := zone.Intersection(city)
+	require.NoError(t, err)
+	assert.InDelta(t, 25, clipped.Area(), 1e-9)
+
+	parts := NewPolygon([]geom.Coord{{4, -1}, {6, -1}, {6, 11}, {4, 11}, {4, -1}})
+
+	_, err = zone.Difference(parts)
+	require.ErrorIs(t, err, ErrUnexpectedGeometryType)
+
+	multi, err := New(geom.NewMultiPolygon(geom.XY).SetSRID(SRID)).Union(zone)
+	require.NoError(t, err)
+	assert.Equal(t, 1, multi.Geom.NumPolygons())
+
+	empty, err := zone.Intersection(nil)
+	require.NoError(t, err)
+	assert.True(t, empty.Geom.Empty())
+
+	_, err = zone.Union(NewPoint(1, 1))
+	require.ErrorIs(t, err, ErrUnexpectedGeometryType)
+
+	_, err = zone.Union(New(geom.NewPolygon(geom.XY).SetSRID(3857)))
+	require.ErrorIs(t, err, ErrMixedSRID)
+}
+
+func TestGeometryOverlayAreas(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 200; i++ {
+		a, err := NewLineString(geom.Coord{r.Float64() * 10, r.Float64() * 10}, geom.Coord{r.Float64() * 10, r.Float64() * 10}).Buffer(1 + r.Float64())
+		require.NoError(t, err)
+
+		b, err := NewPoint(math.Round(r.Float64()*10), math.Round(r.Float64()*10)).Buffer(1 + math.Round(r.Float64()*3))
+		require.NoError(t, err)
+
+		ga, gb := New(a.GeomT()), New(b.GeomT())
+
+		intersection, err := ga.Intersection(gb)
+		require.NoError(t, err)
+
+		union, err := ga.Union(gb)
+		require.NoError(t, err)
+
+		difference, err := ga.Difference(gb)
+		require.NoError(t, err)
+
+		symDifference, err := ga.SymDifference(gb)
+		require.NoError(t, err)
+
+		for _, g := range []Geometry[geom.T]{intersection, union, difference, symDifference} {
+			require.True(t, g.IsValid(), g.String())
+		}
+
+		assert.InDelta(t, a.Area()+b.Area()-intersection.Area(), union.Area(), 1e-9)
+		assert.InDelta(t, a.Area()-intersection.Area(), difference.Area(), 1e-9)
+		assert.InDelta(t, union.Area()-intersection.Area(), symDifference.Area(), 1e-9)
+	}
+}
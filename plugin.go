@@ -0,0 +1,327 @@
+package georm
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var ErrInvalidSimplify = errors.New("invalid simplify tag")
+
+// Plugin applies `georm` tags of geometry fields on create, update and query,
+// register it with db.Use(georm.Plugin{}).
+//
+// Supported tags:
+//   - precision=N rounds coordinates of the written geometry to N decimal
+//     places (see SnapToGrid) in Create, Save and Updates, the model and
+//     values of the caller keep their geometries. Value does not know tags
+//     of the field, so geometries passed to raw SQL are not rounded,
+//     use SnapToGrid for them
+//   - simplify=TOLERANCE;source=Field fills the field after query with
+//     a simplified copy of another stored field of the same geometry type
+//     (see Simplify), with preserveTopology flag SimplifyPreserveTopology
+//     is used. The field is not stored: `gorm:"-"`, otherwise Save would
+//     write the simplified geometry back
+//   - tolerance=X is the tolerance of change detection in Save and Updates
+//     instead of ChangeTolerance, see Snapshot
+//   - relation=PREDICATE declares a slice (or a pointer) of related models
+//     matching the predicate (contains, within, intersects, touches, covers
+//     or disjoint) between the model geometry and foreignGeom=COLUMN of
+//     related models, geom=COLUMN selects the model geometry when it has
+//     several ones. db.Preload loads such fields with one join query for
+//     all models, the field is not stored: `gorm:"-"`
+type Plugin struct{}
+
+// Name impl gorm.Plugin
+func (Plugin) Name() string { return "georm" }
+
+// Initialize impl gorm.Plugin
+func (Plugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("georm:before_create", beforeWrite); err != nil {
+		return err
+	}
+
+	if err := db.Callback().Create().After("gorm:create").Register("georm:after_create", afterCreate); err != nil {
+		return err
+	}
+
+	if err := db.Callback().Create().After("georm:after_create").Register("georm:after_write", afterWrite); err != nil {
+		return err
+	}
+
+	err := db.Callback().Update().After("gorm:setup_reflect_value").Before("gorm:before_update").
+		Register("georm:omit_unchanged", omitUnchanged)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Callback().Update().Before("gorm:update").Register("georm:before_update", beforeWrite); err != nil {
+		return err
+	}
+
+	if err := db.Callback().Update().After("gorm:update").Register("georm:after_update", afterUpdate); err != nil {
+		return err
+	}
+
+	if err := db.Callback().Update().After("georm:after_update").Register("georm:after_write", afterWrite); err != nil {
+		return err
+	}
+
+	if err := db.Callback().Query().After("gorm:query").Before("gorm:preload").Register("georm:preload", preloadRelations); err != nil {
+		return err
+	}
+
+	return db.Callback().Query().After("gorm:after_query").Register("georm:after_query", afterQuery)
+}
+
+// TagSettings returns parsed `georm:"key=value;flag"` tag of the field, keys are upper cased
+func TagSettings(field *schema.Field) map[string]string {
+	settings := map[string]string{}
+
+	for _, setting := range strings.Split(field.Tag.Get("georm"), ";") {
+		key, value, _ := strings.Cut(setting, "=")
+		if key = strings.TrimSpace(key); key != "" {
+			settings[strings.ToUpper(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return settings
+}
+
+func fieldPrecision(field *schema.Field) (int, bool) {
+	value, ok := TagSettings(field)["PRECISION"]
+	if !ok {
+		return 0, false
+	}
+
+	precision, err := strconv.Atoi(value)
+
+	return precision, err == nil
+}
+
+// simplification fills a field not stored in the database with a simplified
+// copy of a stored source field
+type simplification struct {
+	source           *schema.Field
+	tolerance        float64
+	preserveTopology bool
+}
+
+// fieldSimplification returns simplification of the field with simplify tag.
+// Stored fields are rejected: Save would write the simplified geometry back
+func fieldSimplification(field *schema.Field) (s simplification, err error) {
+	settings := TagSettings(field)
+
+	if field.DBName != "" {
+		return s, fmt.Errorf("%w: %s.%s: stored field cannot be simplified, use gorm:\"-\" with source", ErrInvalidSimplify, field.Schema.Name, field.Name)
+	}
+
+	if s.tolerance, err = strconv.ParseFloat(settings["SIMPLIFY"], 64); err != nil {
+		return s, fmt.Errorf("%w: %s.%s: %w", ErrInvalidSimplify, field.Schema.Name, field.Name, err)
+	}
+
+	name := settings["SOURCE"]
+
+	s.source = field.Schema.LookUpField(name)
+	if s.source == nil || s.source.DBName == "" || s.source.IndirectFieldType != field.IndirectFieldType {
+		return s, fmt.Errorf("%w: %s.%s: source %q is not a stored field of the same type", ErrInvalidSimplify, field.Schema.Name, field.Name, name)
+	}
+
+	_, s.preserveTopology = settings["PRESERVETOPOLOGY"]
+
+	return s, nil
+}
+
+// precisionSettingsKey stores snappedValues in Statement.Settings
+const precisionSettingsKey = "georm:precision"
+
+// snappedValues keeps values of the caller replaced with snapped ones
+// for the write, they are restored after it. dest is the map of Updates
+// replaced with a snapped copy
+type snappedValues struct {
+	dest   map[string]interface{}
+	fields []reflect.Value
+	values []reflect.Value
+}
+
+// beforeWrite snaps geometry fields with precision tag to the grid
+// in the written model or values
+func beforeWrite(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil {
+		return
+	}
+
+	snapped := &snappedValues{}
+
+	for _, field := range stmt.Schema.Fields {
+		precision, ok := fieldPrecision(field)
+		if !ok {
+			continue
+		}
+
+		snapped.snap(db, field, stmt.ReflectValue, precision)
+
+		switch dest := stmt.Dest.(type) {
+		case map[string]interface{}:
+			for key, value := range dest {
+				if f := stmt.Schema.LookUpField(key); f == field {
+					if snapper, ok := value.(gridSnapper); ok && !reflect.ValueOf(value).IsZero() {
+						if snapped.dest == nil {
+							// the map of the caller is not changed
+							snapped.dest, dest = dest, maps.Clone(dest)
+							stmt.Dest = dest
+						}
+
+						dest[key] = snapper.snapToGrid(precision)
+					}
+				}
+			}
+		default:
+			// Updates with a struct other than the model
+			if destValue := reflect.Indirect(reflect.ValueOf(dest)); destValue.IsValid() {
+				snapped.snap(db, field, destValue, precision)
+			}
+		}
+	}
+
+	if snapped.dest != nil || len(snapped.fields) > 0 {
+		stmt.Settings.Store(precisionSettingsKey, snapped)
+	}
+}
+
+// snap snaps the field of a model or a slice of models
+func (s *snappedValues) snap(db *gorm.DB, field *schema.Field, rv reflect.Value, precision int) {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			s.snap(db, field, reflect.Indirect(rv.Index(i)), precision)
+		}
+	case reflect.Struct:
+		if rv.Type() != field.Schema.ModelType || !rv.CanAddr() {
+			return
+		}
+
+		fv := field.ReflectValueOf(db.Statement.Context, rv)
+
+		value := fv
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return
+			}
+
+			value = value.Elem()
+		}
+
+		snapper, ok := value.Interface().(gridSnapper)
+		if !ok || value.IsZero() {
+			return
+		}
+
+		snapped := reflect.ValueOf(snapper.snapToGrid(precision))
+
+		// pointer fields get a new pointer, the geometry of the caller is not changed
+		if fv.Kind() == reflect.Pointer {
+			ptr := reflect.New(snapped.Type())
+			ptr.Elem().Set(snapped)
+			snapped = ptr
+		}
+
+		s.fields = append(s.fields, fv)
+		s.values = append(s.values, reflect.ValueOf(fv.Interface()))
+
+		fv.Set(snapped)
+	}
+}
+
+// afterWrite restores values of the caller snapped by beforeWrite,
+// after the snapshot of written geometries is taken
+func afterWrite(db *gorm.DB) {
+	value, ok := db.Statement.Settings.LoadAndDelete(precisionSettingsKey)
+	if !ok {
+		return
+	}
+
+	snapped := value.(*snappedValues)
+
+	// in reverse order, the model of Save is snapped both as the model and as dest
+	for i := len(snapped.fields) - 1; i >= 0; i-- {
+		snapped.fields[i].Set(snapped.values[i])
+	}
+
+	if snapped.dest != nil {
+		db.Statement.Dest = snapped.dest
+	}
+}
+
+func afterQuery(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil {
+		return
+	}
+
+	takeSnapshot(db, stmt.ReflectValue)
+
+	for _, field := range stmt.Schema.Fields {
+		if _, ok := TagSettings(field)["SIMPLIFY"]; !ok {
+			continue
+		}
+
+		s, err := fieldSimplification(field)
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+
+		simplifyValues(db, field, s, stmt.ReflectValue)
+	}
+}
+
+// simplifyValues sets the field of a model or a slice of models to the simplified source field
+func simplifyValues(db *gorm.DB, field *schema.Field, s simplification, rv reflect.Value) {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			simplifyValues(db, field, s, reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		if rv.Type() != field.Schema.ModelType || !rv.CanAddr() {
+			return
+		}
+
+		fv := field.ReflectValueOf(db.Statement.Context, rv)
+
+		sv := s.source.ReflectValueOf(db.Statement.Context, rv)
+		if sv.Kind() == reflect.Pointer {
+			if sv.IsNil() {
+				fv.Set(reflect.Zero(fv.Type()))
+				return
+			}
+
+			sv = sv.Elem()
+		}
+
+		simplifier, ok := sv.Interface().(geometrySimplifier)
+		if !ok {
+			return
+		}
+
+		simplified := reflect.ValueOf(simplifier.simplify(s.tolerance, s.preserveTopology))
+
+		if fv.Kind() == reflect.Pointer {
+			ptr := reflect.New(simplified.Type())
+			ptr.Elem().Set(simplified)
+			fv.Set(ptr)
+
+			return
+		}
+
+		fv.Set(simplified)
+	}
+}
@@ -0,0 +1,209 @@
+package georm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+	"gorm.io/gorm"
+)
+
+type testTrack struct {
+	ID       uint        `gorm:"primaryKey"`
+	Start    Point       `georm:"precision=3"`
+	Path     *LineString `georm:"precision=1"`
+	Original Point
+}
+
+func pluginDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db := dryRunDB(t)
+	require.NoError(t, db.Use(Plugin{}))
+
+	// dry run cannot begin a transaction without connection
+	return db.Session(&gorm.Session{SkipDefaultTransaction: true})
+}
+
+// writtenGeometries returns geometries of the statement vars
+func writtenGeometries(tx *gorm.DB) []geom.T {
+	var geometries []geom.T
+
+	for _, v := range tx.Statement.Vars {
+		if geometry, ok := geometryOf(v); ok && geometry != nil {
+			geometries = append(geometries, geometry)
+		}
+	}
+
+	return geometries
+}
+
+func TestPluginPrecisionCreate(t *testing.T) {
+	db := pluginDB(t)
+
+	track := testTrack{
+		Start:    New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{37.6176345, 55.7558254})),
+		Path:     &LineString{geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0.01, 0.01}, {0.02, 0.02}, {1.04, 1.01}})},
+		Original: New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{37.6176345, 55.7558254})),
+	}
+
+	tx := db.Create(&track)
+	require.NoError(t, tx.Error)
+
+	written := writtenGeometries(tx)
+	require.Len(t, written, 3)
+	assert.Equal(t, geom.Coord{37.618, 55.756}, written[0].(*geom.Point).Coords())
+	assert.Equal(t, []geom.Coord{{0, 0}, {1, 1}}, written[1].(*geom.LineString).Coords())
+	assert.Equal(t, geom.Coord{37.6176345, 55.7558254}, written[2].(*geom.Point).Coords())
+
+	// the model keeps its geometries
+	assert.Equal(t, geom.Coord{37.6176345, 55.7558254}, track.Start.Geom.Coords())
+	assert.Equal(t, []geom.Coord{{0.01, 0.01}, {0.02, 0.02}, {1.04, 1.01}}, track.Path.Geom.Coords())
+
+	tracks := []testTrack{{Start: New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1.0001, 2.0001}))}}
+
+	tx = db.Create(&tracks)
+	require.NoError(t, tx.Error)
+
+	assert.Equal(t, geom.Coord{1, 2}, writtenGeometries(tx)[0].(*geom.Point).Coords())
+	assert.Equal(t, geom.Coord{1.0001, 2.0001}, tracks[0].Start.Geom.Coords())
+}
+
+func TestPluginPrecisionUpdates(t *testing.T) {
+	db := pluginDB(t)
+
+	values := map[string]interface{}{"start": New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1.0001, 2.0001}))}
+
+	tx := db.Model(&testTrack{ID: 1}).Updates(values)
+	require.NoError(t, tx.Error)
+
+	assert.Equal(t, geom.Coord{1, 2}, writtenGeometries(tx)[0].(*geom.Point).Coords())
+	assert.Equal(t, geom.Coord{1.0001, 2.0001}, values["start"].(Point).Geom.Coords())
+	assert.Equal(t, values, tx.Statement.Dest)
+
+	track := testTrack{Start: New(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{3.0001, 4.0001}))}
+
+	tx = db.Model(&testTrack{ID: 1}).Updates(&track)
+	require.NoError(t, tx.Error)
+
+	assert.Equal(t, geom.Coord{3, 4}, writtenGeometries(tx)[0].(*geom.Point).Coords())
+	assert.Equal(t, geom.Coord{3.0001, 4.0001}, track.Start.Geom.Coords())
+
+	tx = db.Save(&track)
+	require.NoError(t, tx.Error)
+
+	assert.Equal(t, geom.Coord{3, 4}, writtenGeometries(tx)[0].(*geom.Point).Coords())
+	assert.Equal(t, geom.Coord{3.0001, 4.0001}, track.Start.Geom.Coords())
+}
+
+func TestPluginPrecisionSnapshot(t *testing.T) {
+	db := pluginDB(t)
+
+	type snappedTrack struct {
+		Snapshot
+
+		ID    uint `gorm:"primaryKey"`
+		Name  string
+		Start Point `georm:"precision=3"`
+	}
+
+	track := snappedTrack{Start: NewPoint(37.6176345, 55.7558254)}
+	require.NoError(t, db.Create(&track).Error)
+
+	// the snapshot keeps the written geometry, the model is compared after snapping
+	assert.Equal(t, geom.Coord{37.618, 55.756}, track.geometries["Start"].(*geom.Point).Coords())
+
+	track.ID, track.Name = 1, "track"
+
+	tx := db.Save(&track)
+	require.NoError(t, tx.Error)
+	assert.NotContains(t, tx.Statement.SQL.String(), `"start"`)
+}
+
+func TestTagSettings(t *testing.T) {
+	db := dryRunDB(t)
+
+	type model struct {
+		Geom Polygon `georm:"precision=7; simplify ;foreignGeom=geo_point"`
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	require.NoError(t, stmt.Parse(&model{}))
+
+	assert.Equal(t, map[string]string{"PRECISION": "7", "SIMPLIFY": "", "FOREIGNGEOM": "geo_point"}, TagSettings(stmt.Schema.LookUpField("Geom")))
+}
+
+type testRoute struct {
+	ID      uint `gorm:"primaryKey"`
+	Path    LineString
+	PathMid LineString  `gorm:"-" georm:"simplify=0.5;source=Path"`
+	PathLow *LineString `gorm:"-" georm:"simplify=2;source=Path"`
+	Zone    Polygon
+	ZoneLow Polygon `gorm:"-" georm:"simplify=10;preserveTopology;source=Zone"`
+}
+
+func TestPluginSimplifyQuery(t *testing.T) {
+	db := pluginDB(t)
+
+	path := []geom.Coord{{0, 0}, {1, 0.1}, {2, 1}, {3, 0}, {4, 0}}
+	zone := []geom.Coord{{0, 0}, {1, 0}, {0, 1}, {0, 0}}
+
+	// dry run does not scan rows, dest values are simplified as queried
+	routes := []testRoute{{Path: NewLineString(path...), Zone: NewPolygon(zone)}}
+	require.NoError(t, db.Find(&routes).Error)
+
+	assert.Equal(t, path, routes[0].Path.Coords())
+	assert.Equal(t, []geom.Coord{{0, 0}, {2, 1}, {4, 0}}, routes[0].PathMid.Coords())
+	assert.Equal(t, []geom.Coord{{0, 0}, {4, 0}}, routes[0].PathLow.Coords())
+	assert.Equal(t, zone, routes[0].ZoneLow.Coords())
+
+	route := testRoute{Path: NewLineString(path...)}
+	require.NoError(t, db.First(&route).Error)
+
+	assert.Equal(t, []geom.Coord{{0, 0}, {4, 0}}, route.PathLow.Coords())
+
+	empty := testRoute{PathLow: &LineString{}}
+	require.NoError(t, db.First(&empty).Error)
+
+	assert.Nil(t, empty.PathLow.Geom)
+	assert.Nil(t, empty.ZoneLow.Geom)
+}
+
+func TestPluginSimplifyInvalid(t *testing.T) {
+	db := pluginDB(t)
+
+	type stored struct {
+		ID   uint       `gorm:"primaryKey"`
+		Path LineString `georm:"simplify=0.5"`
+	}
+
+	type noSource struct {
+		ID      uint `gorm:"primaryKey"`
+		Path    LineString
+		PathLow LineString `gorm:"-" georm:"simplify=0.5"`
+	}
+
+	type notStoredSource struct {
+		ID      uint `gorm:"primaryKey"`
+		Path    LineString
+		PathMid LineString `gorm:"-" georm:"simplify=0.5;source=Path"`
+		PathLow LineString `gorm:"-" georm:"simplify=2;source=PathMid"`
+	}
+
+	type otherType struct {
+		ID      uint `gorm:"primaryKey"`
+		Zone    Polygon
+		PathLow LineString `gorm:"-" georm:"simplify=0.5;source=Zone"`
+	}
+
+	type badTolerance struct {
+		ID      uint `gorm:"primaryKey"`
+		Path    LineString
+		PathLow LineString `gorm:"-" georm:"simplify=low;source=Path"`
+	}
+
+	for _, dest := range []interface{}{&stored{}, &noSource{}, &notStoredSource{}, &otherType{}, &badTolerance{}} {
+		assert.ErrorIs(t, db.Find(dest).Error, ErrInvalidSimplify)
+	}
+}
@@ -0,0 +1,432 @@
+package georm
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/xy"
+	"github.com/twpayne/go-geom/xy/location"
+)
+
+// Geometric is implemented by all Geometry[T] types
+type Geometric interface {
+	GeomT() geom.T
+}
+
+// GeomT returns the geometry as geom.T, nil for nil geometry
+func (g Geometry[T]) GeomT() geom.T {
+	if isNil(g.Geom) {
+		return nil
+	}
+
+	return g.Geom
+}
+
+// Predicate is a spatial relationship between two geometries,
+// evaluated in Go by Eval and in PostGIS by the function Name
+type Predicate struct {
+	name string
+	eval func(a, b *relateGeometry) bool
+}
+
+// Spatial predicates with semantics of PostGIS functions of the same name,
+// empty geometries neither intersect nor contain anything
+var (
+	Intersects = Predicate{name: "ST_Intersects", eval: func(a, b *relateGeometry) bool { return intersects(a, b) }}
+	Disjoint   = Predicate{name: "ST_Disjoint", eval: func(a, b *relateGeometry) bool { return !intersects(a, b) }}
+	Touches    = Predicate{name: "ST_Touches", eval: touches}
+	Contains   = Predicate{name: "ST_Contains", eval: contains}
+	Within     = Predicate{name: "ST_Within", eval: func(a, b *relateGeometry) bool { return contains(b, a) }}
+	Covers     = Predicate{name: "ST_Covers", eval: covers}
+)
+
+// Name returns PostGIS function of the predicate, e.g. ST_Intersects
+func (p Predicate) Name() string { return p.name }
+
+// Eval evaluates the predicate for geometries a and b
+func (p Predicate) Eval(a, b Geometric) bool {
+	return p.eval(newRelateGeometry(a.GeomT()), newRelateGeometry(b.GeomT()))
+}
+
+// Intersects reports whether geometries have at least one common point
+func (g Geometry[T]) Intersects(other Geometric) bool { return Intersects.Eval(g, other) }
+
+// Disjoint reports whether geometries have no common points
+func (g Geometry[T]) Disjoint(other Geometric) bool { return Disjoint.Eval(g, other) }
+
+// Touches reports whether geometries have common points, but their interiors do not intersect
+func (g Geometry[T]) Touches(other Geometric) bool { return Touches.Eval(g, other) }
+
+// Contains reports whether no points of other lie in the exterior of g
+// and at least one point of the interior of other lies in the interior of g
+func (g Geometry[T]) Contains(other Geometric) bool { return Contains.Eval(g, other) }
+
+// Within reports whether g lies within other, i.e. other contains g
+func (g Geometry[T]) Within(other Geometric) bool { return Within.Eval(g, other) }
+
+// Covers reports whether no points of other lie in the exterior of g,
+// unlike Contains points of other on the boundary of g are enough
+func (g Geometry[T]) Covers(other Geometric) bool { return Covers.Eval(g, other) }
+
+func intersects(a, b *relateGeometry) bool {
+	if a.empty() || b.empty() || !a.bounds.Overlaps(geom.XY, b.bounds) {
+		return false
+	}
+
+	for _, p := range relateCandidates(a, b) {
+		if a.locate(p.coord) != location.Exterior && b.locate(p.coord) != location.Exterior {
+			return true
+		}
+	}
+
+	return false
+}
+
+func touches(a, b *relateGeometry) bool {
+	return intersects(a, b) && !interiorsIntersect(a, b)
+}
+
+func contains(a, b *relateGeometry) bool {
+	return covers(a, b) && interiorsIntersect(a, b)
+}
+
+func covers(a, b *relateGeometry) bool {
+	if a.empty() || b.empty() || a.dimension < b.dimension || !boundsCover(a.bounds, b.bounds) {
+		return false
+	}
+
+	for _, p := range relateCandidates(a, b) {
+		switch {
+		case p.owner&ownerB != 0 && a.locate(p.coord) == location.Exterior:
+			return false
+		case p.owner == ownerA && !p.interior && a.dimension == 2 && b.dimension == 2 && b.locate(p.coord) == location.Interior:
+			// boundary of area a inside area b leaves a part of b uncovered
+			return false
+		}
+	}
+
+	return true
+}
+
+// interiorsIntersect reports whether interiors of geometries have a common point
+func interiorsIntersect(a, b *relateGeometry) bool {
+	if a.empty() || b.empty() || !a.bounds.Overlaps(geom.XY, b.bounds) {
+		return false
+	}
+
+	for _, p := range relateCandidates(a, b) {
+		la, lb := a.locate(p.coord), b.locate(p.coord)
+
+		switch {
+		case la == location.Interior && lb == location.Interior:
+			return true
+		case a.dimension == 2 && b.dimension == 2 && (la == location.Interior || lb == location.Interior) &&
+			la != location.Exterior && lb != location.Exterior:
+			// boundary of one area inside another area, interiors are near
+			return true
+		}
+	}
+
+	return false
+}
+
+func boundsCover(a, b *geom.Bounds) bool {
+	return a.Min(0) <= b.Min(0) && a.Min(1) <= b.Min(1) && a.Max(0) >= b.Max(0) && a.Max(1) >= b.Max(1)
+}
+
+// relateGeometry is geometry prepared for evaluation of predicates
+type relateGeometry struct {
+	points   []geom.Coord
+	lines    []*geom.LineString
+	polygons []*geom.Polygon
+
+	// endpoints counts ends of lines, ends counted odd times form the line boundary
+	endpoints map[[2]float64]int
+
+	// segments of lines and polygon rings
+	segments []segment
+
+	// dimension of the highest dimension parts, -1 for empty geometry
+	dimension int
+	bounds    *geom.Bounds
+}
+
+func newRelateGeometry(g geom.T) *relateGeometry {
+	r := &relateGeometry{endpoints: map[[2]float64]int{}, dimension: -1, bounds: geom.NewBounds(geom.XY)}
+
+	if isNil(g) {
+		return r
+	}
+
+	eachPart(g, func(part geom.T) {
+		flatCoords, stride := part.FlatCoords(), part.Stride()
+		if len(flatCoords) == 0 {
+			return
+		}
+
+		switch part := part.(type) {
+		case *geom.Point:
+			r.points = append(r.points, geom.Coord(flatCoords[:2]))
+			r.dimension = max(r.dimension, 0)
+		case *geom.LineString:
+			r.lines = append(r.lines, part)
+			r.segments = append(r.segments, ringSegments(flatCoords, stride)...)
+			r.dimension = max(r.dimension, 1)
+
+			if !part.Coord(0).Equal(geom.XY, part.Coord(part.NumCoords()-1)) {
+				r.endpoints[[2]float64{flatCoords[0], flatCoords[1]}]++
+				r.endpoints[[2]float64{flatCoords[len(flatCoords)-stride], flatCoords[len(flatCoords)-stride+1]}]++
+			}
+		case *geom.Polygon:
+			r.polygons = append(r.polygons, part)
+			r.dimension = 2
+
+			for i := 0; i < part.NumLinearRings(); i++ {
+				r.segments = append(r.segments, ringSegments(part.LinearRing(i).FlatCoords(), stride)...)
+			}
+		default:
+			return
+		}
+
+		for i := 0; i < len(flatCoords); i += stride {
+			r.bounds.Extend(geom.NewPointFlat(geom.XY, flatCoords[i:i+2]))
+		}
+	})
+
+	return r
+}
+
+func (r *relateGeometry) empty() bool { return r.dimension < 0 }
+
+// locate returns location of the point relative to the geometry,
+// interior of any part wins over boundary of other parts
+func (r *relateGeometry) locate(c geom.Coord) location.Type {
+	result := location.Exterior
+
+	for _, p := range r.polygons {
+		switch locatePointInPolygon(p, c) {
+		case location.Interior:
+			return location.Interior
+		case location.Boundary:
+			result = location.Boundary
+		}
+	}
+
+	for _, l := range r.lines {
+		if !pointOnLine(l.FlatCoords(), l.Stride(), c) {
+			continue
+		}
+
+		if r.endpoints[[2]float64{c[0], c[1]}]%2 == 0 {
+			return location.Interior
+		}
+
+		result = location.Boundary
+	}
+
+	for _, p := range r.points {
+		if p.Equal(geom.XY, c) {
+			return location.Interior
+		}
+	}
+
+	return result
+}
+
+func pointOnLine(flatCoords []float64, stride int, c geom.Coord) bool {
+	for i := stride; i < len(flatCoords); i += stride {
+		s := segment{start: flatCoords[i-stride : i-stride+2], end: flatCoords[i : i+2]}
+		if orientation(s.start, s.end, c) == 0 && onSegment(s, c) {
+			return true
+		}
+	}
+
+	return len(flatCoords) == stride && flatCoords[0] == c[0] && flatCoords[1] == c[1]
+}
+
+// locatePointInPolygon locates the point relative to polygon with holes
+func locatePointInPolygon(p *geom.Polygon, c geom.Coord) location.Type {
+	if p.NumLinearRings() == 0 {
+		return location.Exterior
+	}
+
+	layout := p.Layout()
+
+	loc := xy.LocatePointInRing(layout, c, p.LinearRing(0).FlatCoords())
+	if loc != location.Interior {
+		return loc
+	}
+
+	for i := 1; i < p.NumLinearRings(); i++ {
+		switch xy.LocatePointInRing(layout, c, p.LinearRing(i).FlatCoords()) {
+		case location.Interior:
+			return location.Exterior
+		case location.Boundary:
+			return location.Boundary
+		}
+	}
+
+	return location.Interior
+}
+
+type candidateOwner int
+
+const (
+	ownerA candidateOwner = 1 << iota
+	ownerB
+)
+
+// relateCandidate is a point where locations relative to geometries are checked
+type relateCandidate struct {
+	coord geom.Coord
+	owner candidateOwner
+
+	// interior is true for interior points of polygons
+	interior bool
+}
+
+// relateCandidates returns points representing all parts of topology of the pair:
+// vertices, intersection points, midpoints of segments split at intersections
+// and interior points of polygons. Location of a midpoint relative to the other
+// geometry is the same as of the whole split segment
+func relateCandidates(a, b *relateGeometry) []relateCandidate {
+	var (
+		candidates []relateCandidate
+		nodesA     = make([][]geom.Coord, len(a.segments))
+		nodesB     = make([][]geom.Coord, len(b.segments))
+	)
+
+	// nil segments of the second geometry would mean self pairs
+	segmentPairs(a.segments, nonNil(b.segments), func(i, j int) bool {
+		sa, sb := a.segments[i], b.segments[j]
+
+		r := intersect(sa, sb)
+
+		switch r.kind {
+		case pointIntersection:
+			nodesA[i] = append(nodesA[i], r.at)
+			nodesB[j] = append(nodesB[j], r.at)
+			candidates = append(candidates, relateCandidate{coord: r.at, owner: ownerA | ownerB})
+		case collinearIntersection:
+			for _, c := range []geom.Coord{sb.start, sb.end} {
+				if onSegment(sa, c) {
+					nodesA[i] = append(nodesA[i], c)
+				}
+			}
+
+			for _, c := range []geom.Coord{sa.start, sa.end} {
+				if onSegment(sb, c) {
+					nodesB[j] = append(nodesB[j], c)
+				}
+			}
+		}
+
+		return true
+	})
+
+	candidates = a.appendCandidates(candidates, nodesA, ownerA)
+	candidates = b.appendCandidates(candidates, nodesB, ownerB)
+
+	return candidates
+}
+
+func nonNil(segments []segment) []segment {
+	if segments == nil {
+		return []segment{}
+	}
+
+	return segments
+}
+
+func (r *relateGeometry) appendCandidates(candidates []relateCandidate, nodes [][]geom.Coord, owner candidateOwner) []relateCandidate {
+	for _, p := range r.points {
+		candidates = append(candidates, relateCandidate{coord: p, owner: owner})
+	}
+
+	for i, s := range r.segments {
+		splits := append([]geom.Coord{s.start}, nodes[i]...)
+		slices.SortFunc(splits, func(c1, c2 geom.Coord) int {
+			return cmp.Compare(distance2(s.start, c1), distance2(s.start, c2))
+		})
+
+		splits = append(splits, s.end)
+
+		candidates = append(candidates, relateCandidate{coord: s.start, owner: owner})
+
+		for k := 1; k < len(splits); k++ {
+			start, end := splits[k-1], splits[k]
+			if start.Equal(geom.XY, end) {
+				continue
+			}
+
+			candidates = append(candidates, relateCandidate{coord: geom.Coord{(start[0] + end[0]) / 2, (start[1] + end[1]) / 2}, owner: owner})
+		}
+
+		candidates = append(candidates, relateCandidate{coord: s.end, owner: owner})
+	}
+
+	for _, p := range r.polygons {
+		if c, ok := interiorPoint(p); ok {
+			candidates = append(candidates, relateCandidate{coord: c, owner: owner, interior: true})
+		}
+	}
+
+	return candidates
+}
+
+// interiorPoint returns a point strictly inside the polygon: the middle of the widest
+// interior interval of the horizontal line between vertex ordinates near the center
+func interiorPoint(p *geom.Polygon) (geom.Coord, bool) {
+	var (
+		stride = p.Stride()
+		center = (p.Bounds().Min(1) + p.Bounds().Max(1)) / 2
+		below  = math.Inf(-1)
+		above  = math.Inf(1)
+	)
+
+	flatCoords := p.FlatCoords()
+	for i := 1; i < len(flatCoords); i += stride {
+		switch y := flatCoords[i]; {
+		case y <= center && y > below:
+			below = y
+		case y > center && y < above:
+			above = y
+		}
+	}
+
+	if math.IsInf(below, 0) || math.IsInf(above, 0) {
+		return nil, false
+	}
+
+	y := (below + above) / 2
+
+	var xs []float64
+
+	for i := 0; i < p.NumLinearRings(); i++ {
+		ring := p.LinearRing(i).FlatCoords()
+
+		for j := stride; j < len(ring); j += stride {
+			x1, y1, x2, y2 := ring[j-stride], ring[j-stride+1], ring[j], ring[j+1]
+			if (y1 > y) != (y2 > y) {
+				xs = append(xs, x1+(y-y1)*(x2-x1)/(y2-y1))
+			}
+		}
+	}
+
+	slices.Sort(xs)
+
+	var (
+		best  geom.Coord
+		width = -1.0
+	)
+
+	for i := 1; i < len(xs); i += 2 {
+		if w := xs[i] - xs[i-1]; w > width {
+			best, width = geom.Coord{(xs[i] + xs[i-1]) / 2, y}, w
+		}
+	}
+
+	return best, width > 0
+}
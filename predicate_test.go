@@ -0,0 +1,228 @@
+package georm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twpayne/go-geom"
+)
+
+func TestPredicates(t *testing.T) {
+	var (
+		square = NewPolygon([]geom.Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}})
+		holed  = NewPolygon(
+			[]geom.Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+			[]geom.Coord{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}},
+		)
+		inner    = NewPolygon([]geom.Coord{{2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}})
+		edged    = NewPolygon([]geom.Coord{{0, 2}, {4, 2}, {4, 4}, {0, 4}, {0, 2}})
+		adjacent = NewPolygon([]geom.Coord{{10, 0}, {20, 0}, {20, 10}, {10, 10}, {10, 0}})
+		corner   = NewPolygon([]geom.Coord{{10, 10}, {20, 10}, {20, 20}, {10, 20}, {10, 10}})
+		overlap  = NewPolygon([]geom.Coord{{5, 5}, {15, 5}, {15, 15}, {5, 15}, {5, 5}})
+		far      = NewPolygon([]geom.Coord{{50, 50}, {60, 50}, {60, 60}, {50, 50}})
+		hole     = NewPolygon([]geom.Coord{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}})
+		multi    = NewMultiPolygon(
+			[][]geom.Coord{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+			[][]geom.Coord{{{20, 0}, {30, 0}, {30, 10}, {20, 10}, {20, 0}}},
+		)
+
+		crossing = NewLineString(geom.Coord{-5, 5}, geom.Coord{15, 5})
+		insideL  = NewLineString(geom.Coord{1, 1}, geom.Coord{9, 9})
+		onEdge   = NewLineString(geom.Coord{0, 0}, geom.Coord{10, 0})
+		fromEdge = NewLineString(geom.Coord{5, 0}, geom.Coord{5, -5})
+		lineA    = NewLineString(geom.Coord{0, 0}, geom.Coord{10, 10})
+		lineB    = NewLineString(geom.Coord{0, 10}, geom.Coord{10, 0})
+		lineEnd  = NewLineString(geom.Coord{10, 10}, geom.Coord{20, 10})
+		subLine  = NewLineString(geom.Coord{2, 2}, geom.Coord{5, 5})
+
+		inside   = NewPoint(5, 5)
+		boundary = NewPoint(10, 5)
+		outside  = NewPoint(15, 5)
+		inHole   = NewPoint(5, 5)
+		lineMid  = NewPoint(5, 5)
+		lineEndP = NewPoint(0, 0)
+
+		empty Polygon
+	)
+
+	tests := []struct {
+		Name   string
+		A, B   Geometric
+		Expect map[string]bool
+	}{
+		{
+			Name: "polygon point inside",
+			A:    square, B: inside,
+			Expect: map[string]bool{"intersects": true, "contains": true, "covers": true},
+		},
+		{
+			Name: "polygon point on boundary",
+			A:    square, B: boundary,
+			Expect: map[string]bool{"intersects": true, "touches": true, "covers": true},
+		},
+		{
+			Name: "polygon point outside",
+			A:    square, B: outside,
+			Expect: map[string]bool{"disjoint": true},
+		},
+		{
+			Name: "polygon point in hole",
+			A:    holed, B: inHole,
+			Expect: map[string]bool{"disjoint": true},
+		},
+		{
+			Name: "point within polygon",
+			A:    inside, B: square,
+			Expect: map[string]bool{"intersects": true, "within": true},
+		},
+		{
+			Name: "polygon inner polygon",
+			A:    square, B: inner,
+			Expect: map[string]bool{"intersects": true, "contains": true, "covers": true},
+		},
+		{
+			Name: "polygon inner polygon sharing edge",
+			A:    square, B: edged,
+			Expect: map[string]bool{"intersects": true, "contains": true, "covers": true},
+		},
+		{
+			Name: "polygon itself",
+			A:    square, B: square,
+			Expect: map[string]bool{"intersects": true, "contains": true, "covers": true, "within": true},
+		},
+		{
+			Name: "polygons sharing edge",
+			A:    square, B: adjacent,
+			Expect: map[string]bool{"intersects": true, "touches": true},
+		},
+		{
+			Name: "polygons sharing corner",
+			A:    square, B: corner,
+			Expect: map[string]bool{"intersects": true, "touches": true},
+		},
+		{
+			Name: "overlapping polygons",
+			A:    square, B: overlap,
+			Expect: map[string]bool{"intersects": true},
+		},
+		{
+			Name: "far polygons",
+			A:    square, B: far,
+			Expect: map[string]bool{"disjoint": true},
+		},
+		{
+			Name: "polygon with hole and polygon filling the hole",
+			A:    holed, B: hole,
+			Expect: map[string]bool{"intersects": true, "touches": true},
+		},
+		{
+			Name: "polygon with hole and polygon covering the hole",
+			A:    holed, B: square,
+			Expect: map[string]bool{"intersects": true, "within": true},
+		},
+		{
+			Name: "multi polygon point in second polygon",
+			A:    multi, B: NewPoint(25, 5),
+			Expect: map[string]bool{"intersects": true, "contains": true, "covers": true},
+		},
+		{
+			Name: "multi polygon point between polygons",
+			A:    multi, B: outside,
+			Expect: map[string]bool{"disjoint": true},
+		},
+		{
+			Name: "polygon crossing line",
+			A:    square, B: crossing,
+			Expect: map[string]bool{"intersects": true},
+		},
+		{
+			Name: "polygon inside line",
+			A:    square, B: insideL,
+			Expect: map[string]bool{"intersects": true, "contains": true, "covers": true},
+		},
+		{
+			Name: "polygon line on edge",
+			A:    square, B: onEdge,
+			Expect: map[string]bool{"intersects": true, "touches": true, "covers": true},
+		},
+		{
+			Name: "polygon line from edge",
+			A:    square, B: fromEdge,
+			Expect: map[string]bool{"intersects": true, "touches": true},
+		},
+		{
+			Name: "crossing lines",
+			A:    lineA, B: lineB,
+			Expect: map[string]bool{"intersects": true},
+		},
+		{
+			Name: "lines touching at end",
+			A:    lineA, B: lineEnd,
+			Expect: map[string]bool{"intersects": true, "touches": true},
+		},
+		{
+			Name: "line sub line",
+			A:    lineA, B: subLine,
+			Expect: map[string]bool{"intersects": true, "contains": true, "covers": true},
+		},
+		{
+			Name: "line point in the middle",
+			A:    lineA, B: lineMid,
+			Expect: map[string]bool{"intersects": true, "contains": true, "covers": true},
+		},
+		{
+			Name: "line point at the end",
+			A:    lineA, B: lineEndP,
+			Expect: map[string]bool{"intersects": true, "touches": true, "covers": true},
+		},
+		{
+			Name: "equal points",
+			A:    NewPoint(1, 1), B: NewPoint(1, 1),
+			Expect: map[string]bool{"intersects": true, "contains": true, "covers": true, "within": true},
+		},
+		{
+			Name: "different points",
+			A:    NewPoint(1, 1), B: NewPoint(1, 2),
+			Expect: map[string]bool{"disjoint": true},
+		},
+		{
+			Name: "empty",
+			A:    square, B: empty,
+			Expect: map[string]bool{"disjoint": true},
+		},
+	}
+
+	predicates := map[string]func(a, b Geometric) bool{
+		"intersects": Intersects.Eval,
+		"disjoint":   Disjoint.Eval,
+		"touches":    Touches.Eval,
+		"contains":   Contains.Eval,
+		"within":     Within.Eval,
+		"covers":     Covers.Eval,
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			for name, predicate := range predicates {
+				assert.Equal(t, test.Expect[name], predicate(test.A, test.B), name)
+			}
+		})
+	}
+}
+
+func TestGeometryPredicateMethods(t *testing.T) {
+	zone := NewPolygon([]geom.Coord{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}})
+	point := NewPoint(5, 5)
+
+	assert.True(t, zone.Contains(point))
+	assert.True(t, zone.Covers(point))
+	assert.True(t, zone.Intersects(point))
+	assert.True(t, point.Within(zone))
+	assert.False(t, zone.Touches(point))
+	assert.False(t, zone.Disjoint(point))
+}
+
+func TestPredicateName(t *testing.T) {
+	assert.Equal(t, "ST_Intersects", Intersects.Name())
+	assert.Equal(t, "ST_Within", Within.Name())
+}
@@ -0,0 +1,302 @@
+package georm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+var ErrInvalidRelation = errors.New("invalid spatial relation")
+
+// relationPredicates are predicates allowed in the relation tag
+var relationPredicates = []Predicate{Intersects, Disjoint, Touches, Contains, Within, Covers}
+
+// spatialRelation is a field of related models matching the predicate
+// between geometry of the model and foreign geometry of related models
+type spatialRelation struct {
+	field     *schema.Field
+	predicate Predicate
+	owner     joinSide
+	related   joinSide
+}
+
+// fieldRelation returns spatial relation of the field with relation tag
+func fieldRelation(db *gorm.DB, field *schema.Field) (relation spatialRelation, err error) {
+	settings := TagSettings(field)
+	name := settings["RELATION"]
+
+	relation.field = field
+
+	i := slices.IndexFunc(relationPredicates, func(p Predicate) bool { return strings.EqualFold(p.Name(), "ST_"+name) })
+	if i < 0 {
+		return relation, fmt.Errorf("%w: %s.%s: unknown predicate %q", ErrInvalidRelation, field.Schema.Name, field.Name, name)
+	}
+
+	relation.predicate = relationPredicates[i]
+
+	if relation.owner, err = joinSideOf(field.Schema, settings["GEOM"]); err != nil {
+		return relation, fmt.Errorf("%w: %s.%s: %w", ErrInvalidRelation, field.Schema.Name, field.Name, err)
+	}
+
+	elemType := field.IndirectFieldType
+	if elemType.Kind() == reflect.Slice {
+		elemType = elemType.Elem()
+	}
+
+	for elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err = stmt.Parse(reflect.New(elemType).Interface()); err != nil {
+		return relation, fmt.Errorf("%w: %s.%s: %w", ErrInvalidRelation, field.Schema.Name, field.Name, err)
+	}
+
+	if relation.related, err = joinSideOf(stmt.Schema, settings["FOREIGNGEOM"]); err != nil {
+		return relation, fmt.Errorf("%w: %s.%s: foreign %w", ErrInvalidRelation, field.Schema.Name, field.Name, err)
+	}
+
+	if relation.related.table == relation.owner.table {
+		relation.related.alias = "related_" + relation.related.table
+	}
+
+	return relation, nil
+}
+
+// relationGeom returns geometry field by name or the only geometry field of the schema
+func relationGeom(s *schema.Schema, name string) (*schema.Field, error) {
+	if name != "" {
+		if field := s.LookUpField(name); field != nil && isGeometryField(field) {
+			return field, nil
+		}
+
+		return nil, fmt.Errorf("geometry field %s not found in %s", name, s.Name)
+	}
+
+	var found *schema.Field
+
+	for _, field := range s.Fields {
+		if !isGeometryField(field) {
+			continue
+		}
+
+		if found != nil {
+			return nil, fmt.Errorf("several geometry fields in %s", s.Name)
+		}
+
+		found = field
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("geometry field not found in %s", s.Name)
+	}
+
+	return found, nil
+}
+
+// preloadRelations preloads spatial relations of queried models and removes
+// them from Statement.Preloads before gorm preloads other associations
+func preloadRelations(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || len(stmt.Preloads) == 0 {
+		return
+	}
+
+	type preload struct {
+		conds  []interface{}
+		nested map[string][]interface{}
+	}
+
+	var (
+		names    []string
+		preloads = map[string]*preload{}
+	)
+
+	for name, conds := range stmt.Preloads {
+		fieldName, nested, _ := strings.Cut(name, ".")
+
+		field := stmt.Schema.LookUpField(fieldName)
+		if field == nil {
+			continue
+		}
+
+		if _, ok := TagSettings(field)["RELATION"]; !ok {
+			continue
+		}
+
+		delete(stmt.Preloads, name)
+
+		p, ok := preloads[field.Name]
+		if !ok {
+			p = &preload{nested: map[string][]interface{}{}}
+			preloads[field.Name] = p
+			names = append(names, field.Name)
+		}
+
+		if nested == "" {
+			p.conds = conds
+		} else {
+			p.nested[nested] = conds
+		}
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		relation, err := fieldRelation(db, stmt.Schema.LookUpField(name))
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+
+		if err := relation.preload(db, preloads[name].conds, preloads[name].nested); err != nil {
+			_ = db.AddError(err)
+			return
+		}
+	}
+}
+
+// preload finds related models of all queried models with two queries:
+// keys of matching pairs joined by the predicate and related models by keys,
+// as gorm preloads many to many associations
+func (r spatialRelation) preload(db *gorm.DB, conds []interface{}, nested map[string][]interface{}) error {
+	var (
+		ctx      = db.Statement.Context
+		pk       = r.owner.pk
+		relatePK = r.related.pk
+		owners   = map[any][]reflect.Value{}
+		keys     []any
+	)
+
+	eachModel(db.Statement.ReflectValue, r.field.Schema, func(model reflect.Value) {
+		key, zero := pk.ValueOf(ctx, model)
+		if zero {
+			return
+		}
+
+		if _, ok := owners[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		owners[key] = append(owners[key], model)
+	})
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	owner, tx := r.owner, db.Session(&gorm.Session{NewDB: true}).Model(reflect.New(r.field.Schema.ModelType).Interface())
+	if db.Statement.Table != "" {
+		owner.table, owner.alias = db.Statement.Table, db.Statement.Table
+		tx = tx.Table(owner.table)
+	}
+
+	tx = tx.Where(clause.IN{Column: clause.Column{Table: owner.table, Name: pk.DBName}, Values: keys})
+
+	pairs, err := joinKeys(tx, owner, r.related, r.predicate, false)
+	if err != nil {
+		return err
+	}
+
+	var (
+		relatedOf = map[any][]any{}
+		related   []any
+	)
+
+	for _, pair := range pairs {
+		if _, ok := relatedOf[pair[1]]; !ok {
+			related = append(related, pair[1])
+		}
+
+		relatedOf[pair[1]] = append(relatedOf[pair[1]], pair[0])
+	}
+
+	sliceType := r.field.IndirectFieldType
+	if sliceType.Kind() != reflect.Slice {
+		sliceType = reflect.SliceOf(r.field.FieldType)
+	}
+
+	results := reflect.New(sliceType)
+
+	if len(related) > 0 {
+		tx := db.Session(&gorm.Session{NewDB: true}).Model(reflect.New(r.related.schema.ModelType).Interface())
+
+		var inlineConds []interface{}
+
+		for _, cond := range conds {
+			if scope, ok := cond.(func(*gorm.DB) *gorm.DB); ok {
+				tx = scope(tx)
+			} else {
+				inlineConds = append(inlineConds, cond)
+			}
+		}
+
+		for name, conds := range nested {
+			tx = tx.Preload(name, conds...)
+		}
+
+		tx = tx.Where(clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: relatePK.DBName}, Values: related})
+		if err := tx.Find(results.Interface(), inlineConds...).Error; err != nil {
+			return err
+		}
+	}
+
+	values := map[any]reflect.Value{}
+
+	results = results.Elem()
+	for i := 0; i < results.Len(); i++ {
+		result := results.Index(i)
+
+		key, _ := relatePK.ValueOf(ctx, reflect.Indirect(result))
+		for _, owner := range relatedOf[key] {
+			v, ok := values[owner]
+			if !ok {
+				v = reflect.MakeSlice(sliceType, 0, 1)
+			}
+
+			values[owner] = reflect.Append(v, result)
+		}
+	}
+
+	for key, models := range owners {
+		v, ok := values[key]
+		if !ok {
+			v = reflect.MakeSlice(sliceType, 0, 0)
+		}
+
+		for _, model := range models {
+			fv := r.field.ReflectValueOf(ctx, model)
+
+			switch {
+			case fv.Kind() == reflect.Slice:
+				fv.Set(v)
+			case v.Len() > 0:
+				fv.Set(v.Index(0))
+			default:
+				fv.Set(reflect.Zero(fv.Type()))
+			}
+		}
+	}
+
+	return nil
+}
+
+// eachModel calls fn for addressable models of the schema in a model or a slice of models
+func eachModel(rv reflect.Value, s *schema.Schema, fn func(model reflect.Value)) {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			eachModel(reflect.Indirect(rv.Index(i)), s, fn)
+		}
+	case reflect.Struct:
+		if rv.Type() == s.ModelType && rv.CanAddr() {
+			fn(rv)
+		}
+	}
+}
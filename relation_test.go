@@ -0,0 +1,112 @@
+package georm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+	"gorm.io/gorm"
+)
+
+type testZone struct {
+	ID    uint `gorm:"primaryKey"`
+	Area  Polygon
+	Homes []testHome `gorm:"-" georm:"relation=contains;foreignGeom=location"`
+	Route *testRoute `gorm:"-" georm:"relation=intersects;foreignGeom=Path"`
+}
+
+// relationDB returns dry run db with the plugin recording queries
+func relationDB(t *testing.T) (*gorm.DB, *[]string) {
+	t.Helper()
+
+	db := pluginDB(t)
+
+	var queries []string
+
+	err := db.Callback().Query().After("gorm:query").Register("test:record", func(tx *gorm.DB) {
+		queries = append(queries, tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...))
+	})
+	require.NoError(t, err)
+
+	return db, &queries
+}
+
+func TestPluginPreloadRelation(t *testing.T) {
+	db, queries := relationDB(t)
+
+	area := NewPolygon([]geom.Coord{{0, 0}, {10, 0}, {10, 10}, {0, 0}})
+
+	// dry run does not scan rows, dest values are the queried ones
+	zones := []testZone{{ID: 1, Area: area}, {ID: 2, Area: area}, {Area: area}}
+	require.NoError(t, db.Preload("Homes", "name <> ?", "").Preload("Route").Find(&zones).Error)
+
+	joined := strings.Join(*queries, "\n")
+	assert.Contains(t, joined, `SELECT "test_zones"."id" AS "georm_left_key", "test_homes"."id" AS "georm_right_key"`+
+		` FROM "test_zones" JOIN "test_homes" ON ST_Contains("test_zones"."area", "test_homes"."location")`+
+		` WHERE "test_zones"."id" IN (1,2)`)
+	assert.Contains(t, joined, `JOIN "test_routes" ON ST_Intersects("test_zones"."area", "test_routes"."path")`)
+
+	// models without primary key are skipped
+	assert.Nil(t, zones[2].Homes)
+
+	for _, zone := range zones[:2] {
+		assert.NotNil(t, zone.Homes)
+		assert.Empty(t, zone.Homes)
+		assert.Nil(t, zone.Route)
+	}
+}
+
+func TestPluginPreloadRelationErrors(t *testing.T) {
+	type testBadZone struct {
+		ID     uint `gorm:"primaryKey"`
+		Area   Polygon
+		Center Point
+		Homes  []testHome  `gorm:"-" georm:"relation=contains;foreignGeom=location"`
+		Tracks []testTrack `gorm:"-" georm:"relation=contains;geom=area;foreignGeom=start"`
+		Routes []testRoute `gorm:"-" georm:"relation=overlaps;geom=area;foreignGeom=path"`
+		Roads  []testRoute `gorm:"-" georm:"relation=intersects;geom=area;foreignGeom=name"`
+	}
+
+	tests := []struct {
+		Name    string
+		Preload string
+		Expect  string
+	}{
+		{
+			Name:    "several geometries",
+			Preload: "Homes",
+			Expect:  "several geometry fields",
+		},
+		{
+			Name:    "unknown predicate",
+			Preload: "Routes",
+			Expect:  `unknown predicate "overlaps"`,
+		},
+		{
+			Name:    "foreign geometry not found",
+			Preload: "Roads",
+			Expect:  "foreign geometry field name not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			db := pluginDB(t)
+
+			zones := []testBadZone{{ID: 1}}
+
+			err := db.Preload(test.Preload).Find(&zones).Error
+			require.ErrorIs(t, err, ErrInvalidRelation)
+			assert.Contains(t, err.Error(), test.Expect)
+		})
+	}
+
+	// other preloads are left to gorm
+	db := pluginDB(t)
+
+	zones := []testBadZone{{ID: 1}}
+	require.NoError(t, db.Preload("Tracks").Find(&zones).Error)
+	assert.ErrorContains(t, db.Preload("Unknown").Find(&zones).Error, "unsupported relations")
+}
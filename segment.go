@@ -0,0 +1,221 @@
+package georm
+
+import (
+	"math"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/xy"
+)
+
+// segment is a line segment between two xy coordinates
+type segment struct {
+	start, end geom.Coord
+}
+
+type intersectionKind int
+
+const (
+	noIntersection intersectionKind = iota
+
+	// segments touch or cross at a single point
+	pointIntersection
+
+	// collinear segments overlap along a part of positive length
+	collinearIntersection
+)
+
+// segmentIntersection describes how two segments intersect
+type segmentIntersection struct {
+	kind intersectionKind
+
+	// proper is true when segments cross at a point interior to both
+	proper bool
+
+	// at is the intersection point, the start of overlap for collinear segments
+	at geom.Coord
+}
+
+func orientation(a, b, c geom.Coord) int {
+	return int(xy.OrientationIndex(a, b, c))
+}
+
+// onSegment reports whether collinear point p lies within the segment bounds
+func onSegment(s segment, p geom.Coord) bool {
+	return p[0] >= math.Min(s.start[0], s.end[0]) && p[0] <= math.Max(s.start[0], s.end[0]) &&
+		p[1] >= math.Min(s.start[1], s.end[1]) && p[1] <= math.Max(s.start[1], s.end[1])
+}
+
+func intersect(s1, s2 segment) segmentIntersection {
+	var (
+		d1 = orientation(s2.start, s2.end, s1.start)
+		d2 = orientation(s2.start, s2.end, s1.end)
+		d3 = orientation(s1.start, s1.end, s2.start)
+		d4 = orientation(s1.start, s1.end, s2.end)
+	)
+
+	if d1 == 0 && d2 == 0 && d3 == 0 && d4 == 0 {
+		return collinearIntersect(s1, s2)
+	}
+
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return segmentIntersection{kind: pointIntersection, proper: true, at: lineIntersection(s1, s2)}
+	}
+
+	switch {
+	case d1 == 0 && onSegment(s2, s1.start):
+		return segmentIntersection{kind: pointIntersection, at: s1.start}
+	case d2 == 0 && onSegment(s2, s1.end):
+		return segmentIntersection{kind: pointIntersection, at: s1.end}
+	case d3 == 0 && onSegment(s1, s2.start):
+		return segmentIntersection{kind: pointIntersection, at: s2.start}
+	case d4 == 0 && onSegment(s1, s2.end):
+		return segmentIntersection{kind: pointIntersection, at: s2.end}
+	}
+
+	return segmentIntersection{kind: noIntersection}
+}
+
+// collinearIntersect intersects segments lying on the same line
+func collinearIntersect(s1, s2 segment) segmentIntersection {
+	// project on the dominant axis
+	axis := 0
+	if math.Abs(s1.end[0]-s1.start[0])+math.Abs(s2.end[0]-s2.start[0]) <
+		math.Abs(s1.end[1]-s1.start[1])+math.Abs(s2.end[1]-s2.start[1]) {
+		axis = 1
+	}
+
+	ordered := func(s segment) (geom.Coord, geom.Coord) {
+		if s.start[axis] <= s.end[axis] {
+			return s.start, s.end
+		}
+
+		return s.end, s.start
+	}
+
+	min1, max1 := ordered(s1)
+	min2, max2 := ordered(s2)
+
+	start, end := min1, max1
+	if min2[axis] > start[axis] {
+		start = min2
+	}
+
+	if max2[axis] < end[axis] {
+		end = max2
+	}
+
+	switch {
+	case start[axis] > end[axis]:
+		return segmentIntersection{kind: noIntersection}
+	case start[axis] == end[axis]:
+		return segmentIntersection{kind: pointIntersection, at: start}
+	default:
+		return segmentIntersection{kind: collinearIntersection, at: start}
+	}
+}
+
+// lineIntersection returns intersection point of lines through the segments
+func lineIntersection(s1, s2 segment) geom.Coord {
+	var (
+		dx1, dy1 = s1.end[0] - s1.start[0], s1.end[1] - s1.start[1]
+		dx2, dy2 = s2.end[0] - s2.start[0], s2.end[1] - s2.start[1]
+		denom    = dx1*dy2 - dy1*dx2
+		t        = ((s2.start[0]-s1.start[0])*dy2 - (s2.start[1]-s1.start[1])*dx2) / denom
+	)
+
+	return geom.Coord{s1.start[0] + t*dx1, s1.start[1] + t*dy1}
+}
+
+// ringSegments returns segments of the closed ring flat coordinates,
+// zero length segments of repeated points are skipped
+func ringSegments(flatCoords []float64, stride int) []segment {
+	segments := make([]segment, 0, len(flatCoords)/stride)
+
+	for i := stride; i+1 < len(flatCoords); i += stride {
+		start := geom.Coord(flatCoords[i-stride : i-stride+2])
+		end := geom.Coord(flatCoords[i : i+2])
+
+		if start.Equal(geom.XY, end) {
+			continue
+		}
+
+		segments = append(segments, segment{start: start, end: end})
+	}
+
+	return segments
+}
+
+// segmentPairs calls fn for every pair of segments which bounding boxes
+// overlap, pairs are found with a sweep along x axis. fn returns false
+// to stop iteration
+func segmentPairs(a, b []segment, fn func(i, j int) bool) {
+	type entry struct {
+		index  int
+		second bool
+		minX   float64
+		maxX   float64
+	}
+
+	entries := make([]entry, 0, len(a)+len(b))
+
+	for i, s := range a {
+		entries = append(entries, entry{index: i, minX: math.Min(s.start[0], s.end[0]), maxX: math.Max(s.start[0], s.end[0])})
+	}
+
+	for i, s := range b {
+		entries = append(entries, entry{index: i, second: true, minX: math.Min(s.start[0], s.end[0]), maxX: math.Max(s.start[0], s.end[0])})
+	}
+
+	slices.SortFunc(entries, func(e1, e2 entry) int {
+		switch {
+		case e1.minX < e2.minX:
+			return -1
+		case e1.minX > e2.minX:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	segmentOf := func(e entry) segment {
+		if e.second {
+			return b[e.index]
+		}
+
+		return a[e.index]
+	}
+
+	self := b == nil
+
+	for i, e1 := range entries {
+		s1 := segmentOf(e1)
+
+		for _, e2 := range entries[i+1:] {
+			if e2.minX > e1.maxX {
+				break
+			}
+
+			if !self && e1.second == e2.second {
+				continue
+			}
+
+			s2 := segmentOf(e2)
+			if math.Max(s1.start[1], s1.end[1]) < math.Min(s2.start[1], s2.end[1]) ||
+				math.Max(s2.start[1], s2.end[1]) < math.Min(s1.start[1], s1.end[1]) {
+				continue
+			}
+
+			first, second := e1.index, e2.index
+			if !self && e1.second {
+				first, second = e2.index, e1.index
+			} else if self && first > second {
+				first, second = second, first
+			}
+
+			if !fn(first, second) {
+				return
+			}
+		}
+	}
+}
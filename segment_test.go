@@ -0,0 +1,82 @@
+package georm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twpayne/go-geom"
+)
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		Name   string
+		S1, S2 segment
+		Expect segmentIntersection
+	}{
+		{
+			Name:   "crossing",
+			S1:     segment{geom.Coord{0, 0}, geom.Coord{2, 2}},
+			S2:     segment{geom.Coord{0, 2}, geom.Coord{2, 0}},
+			Expect: segmentIntersection{kind: pointIntersection, proper: true, at: geom.Coord{1, 1}},
+		},
+		{
+			Name:   "touching by end",
+			S1:     segment{geom.Coord{0, 0}, geom.Coord{1, 1}},
+			S2:     segment{geom.Coord{0, 2}, geom.Coord{2, 0}},
+			Expect: segmentIntersection{kind: pointIntersection, at: geom.Coord{1, 1}},
+		},
+		{
+			Name:   "disjoint",
+			S1:     segment{geom.Coord{0, 0}, geom.Coord{1, 0}},
+			S2:     segment{geom.Coord{0, 1}, geom.Coord{1, 1}},
+			Expect: segmentIntersection{kind: noIntersection},
+		},
+		{
+			Name:   "collinear overlap",
+			S1:     segment{geom.Coord{0, 0}, geom.Coord{2, 0}},
+			S2:     segment{geom.Coord{3, 0}, geom.Coord{1, 0}},
+			Expect: segmentIntersection{kind: collinearIntersection, at: geom.Coord{1, 0}},
+		},
+		{
+			Name:   "collinear touching",
+			S1:     segment{geom.Coord{0, 0}, geom.Coord{0, 2}},
+			S2:     segment{geom.Coord{0, 2}, geom.Coord{0, 3}},
+			Expect: segmentIntersection{kind: pointIntersection, at: geom.Coord{0, 2}},
+		},
+		{
+			Name:   "collinear disjoint",
+			S1:     segment{geom.Coord{0, 0}, geom.Coord{1, 1}},
+			S2:     segment{geom.Coord{2, 2}, geom.Coord{3, 3}},
+			Expect: segmentIntersection{kind: noIntersection},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expect, intersect(test.S1, test.S2))
+		})
+	}
+}
+
+func TestSegmentPairs(t *testing.T) {
+	segments := ringSegments([]float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}, 2)
+
+	var pairs [][2]int
+	segmentPairs(segments, nil, func(i, j int) bool {
+		pairs = append(pairs, [2]int{i, j})
+		return true
+	})
+
+	// opposite sides of the square do not overlap
+	assert.ElementsMatch(t, [][2]int{{0, 1}, {1, 2}, {2, 3}, {0, 3}}, pairs)
+
+	other := ringSegments([]float64{20, 0, 30, 0, 30, 10, 20, 0}, 2)
+
+	pairs = nil
+	segmentPairs(segments, other, func(i, j int) bool {
+		pairs = append(pairs, [2]int{i, j})
+		return true
+	})
+
+	assert.Empty(t, pairs)
+}
@@ -0,0 +1,387 @@
+package georm
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/xy"
+)
+
+// Simplify returns geometry simplified with the Douglas-Peucker algorithm
+// as PostGIS ST_Simplify: vertices closer than tolerance to the simplified
+// line are removed. Rings collapsed to less than four points are removed,
+// collapsed Polygon shell makes the polygon empty. The result may be invalid,
+// see SimplifyPreserveTopology
+func (g Geometry[T]) Simplify(tolerance float64) Geometry[T] {
+	return g.simplified(func(parts []*simplifiedPart) {
+		for _, part := range parts {
+			part.douglasPeucker(tolerance, 2, nil)
+		}
+	})
+}
+
+// SimplifyPreserveTopology returns geometry simplified with the Douglas-Peucker
+// algorithm as PostGIS ST_SimplifyPreserveTopology: rings keep at least
+// four points and simplified lines and rings do not cross each other
+func (g Geometry[T]) SimplifyPreserveTopology(tolerance float64) Geometry[T] {
+	return g.simplified(func(parts []*simplifiedPart) {
+		for _, part := range parts {
+			part.douglasPeucker(tolerance, part.minPoints(), func(start, end int) bool {
+				return !crossesParts(parts, part, start, end)
+			})
+		}
+	})
+}
+
+// SimplifyVW returns geometry simplified with the Visvalingam-Whyatt algorithm
+// as PostGIS ST_SimplifyVW: vertices forming triangles with neighbours of
+// area less than the given one are removed. Rings keep at least four points
+func (g Geometry[T]) SimplifyVW(area float64) Geometry[T] {
+	return g.simplified(func(parts []*simplifiedPart) {
+		for _, part := range parts {
+			part.visvalingam(area)
+		}
+	})
+}
+
+// simplify returns Simplify or SimplifyPreserveTopology result as any,
+// used by Plugin for geometry fields of any type
+func (g Geometry[T]) simplify(tolerance float64, preserveTopology bool) any {
+	if preserveTopology {
+		return g.SimplifyPreserveTopology(tolerance)
+	}
+
+	return g.Simplify(tolerance)
+}
+
+type geometrySimplifier interface {
+	simplify(tolerance float64, preserveTopology bool) any
+}
+
+func (g Geometry[T]) simplified(simplify func(parts []*simplifiedPart)) Geometry[T] {
+	if isNil(g.Geom) {
+		return g
+	}
+
+	parts := collectParts(g.Geom, nil)
+	simplify(parts)
+
+	simplified, ok := buildSimplified(g.Geom, &parts).(T)
+	if !ok {
+		return g
+	}
+
+	return Geometry[T]{simplified}
+}
+
+// simplifiedPart is a line or a ring of geometry with mask of kept vertices
+type simplifiedPart struct {
+	flatCoords []float64
+	stride     int
+	ring       bool
+	keep       []bool
+	kept       int
+}
+
+func newSimplifiedPart(flatCoords []float64, stride int, ring bool) *simplifiedPart {
+	n := len(flatCoords) / stride
+
+	keep := make([]bool, n)
+	for i := range keep {
+		keep[i] = true
+	}
+
+	return &simplifiedPart{flatCoords: flatCoords, stride: stride, ring: ring, keep: keep, kept: n}
+}
+
+func (p *simplifiedPart) coord(i int) geom.Coord {
+	return p.flatCoords[i*p.stride : i*p.stride+2]
+}
+
+func (p *simplifiedPart) minPoints() int {
+	if p.ring {
+		return 4
+	}
+
+	return 2
+}
+
+// douglasPeucker removes vertices between start and end when all of them
+// are within tolerance of the segment, the part keeps at least minPoints
+// and accept approves the segment, otherwise the section is split at the
+// farthest vertex
+func (p *simplifiedPart) douglasPeucker(tolerance float64, minPoints int, accept func(start, end int) bool) {
+	n := len(p.keep)
+	if n < 3 {
+		return
+	}
+
+	stack := []int{0, n - 1}
+
+	for len(stack) > 0 {
+		start, end := stack[len(stack)-2], stack[len(stack)-1]
+		stack = stack[:len(stack)-2]
+
+		if end-start < 2 {
+			continue
+		}
+
+		var (
+			farthest    = start + 1
+			maxDistance = -1.0
+			a, b        = p.coord(start), p.coord(end)
+		)
+
+		for i := start + 1; i < end; i++ {
+			if d := xy.DistanceFromPointToLine(p.coord(i), a, b); d > maxDistance {
+				farthest, maxDistance = i, d
+			}
+		}
+
+		removed := end - start - 1
+
+		if maxDistance <= tolerance && p.kept-removed >= minPoints && (accept == nil || accept(start, end)) {
+			for i := start + 1; i < end; i++ {
+				p.keep[i] = false
+			}
+
+			p.kept -= removed
+
+			continue
+		}
+
+		stack = append(stack, start, farthest, farthest, end)
+	}
+}
+
+// visvalingam removes vertices by increasing effective area while it is less than area
+func (p *simplifiedPart) visvalingam(area float64) {
+	n := len(p.keep)
+	if n < 3 {
+		return
+	}
+
+	var (
+		prev  = make([]int, n)
+		next  = make([]int, n)
+		queue = make(effectiveAreaQueue, 0, n-2)
+		items = make([]*effectiveArea, n)
+	)
+
+	triangle := func(i int) float64 {
+		a, b, c := p.coord(prev[i]), p.coord(i), p.coord(next[i])
+		return math.Abs((b[0]-a[0])*(c[1]-a[1])-(c[0]-a[0])*(b[1]-a[1])) / 2
+	}
+
+	for i := 0; i < n; i++ {
+		prev[i], next[i] = i-1, i+1
+	}
+
+	for i := 1; i < n-1; i++ {
+		items[i] = &effectiveArea{vertex: i, area: triangle(i)}
+		heap.Push(&queue, items[i])
+	}
+
+	for queue.Len() > 0 && p.kept > p.minPoints() {
+		item := heap.Pop(&queue).(*effectiveArea)
+		if item.area >= area {
+			break
+		}
+
+		i := item.vertex
+		p.keep[i] = false
+		p.kept--
+
+		next[prev[i]], prev[next[i]] = next[i], prev[i]
+
+		for _, neighbour := range []int{prev[i], next[i]} {
+			if neighbour != 0 && neighbour != n-1 {
+				items[neighbour].area = triangle(neighbour)
+				heap.Fix(&queue, items[neighbour].index)
+			}
+		}
+	}
+}
+
+type effectiveArea struct {
+	vertex int
+	area   float64
+	index  int
+}
+
+type effectiveAreaQueue []*effectiveArea
+
+func (q effectiveAreaQueue) Len() int           { return len(q) }
+func (q effectiveAreaQueue) Less(i, j int) bool { return q[i].area < q[j].area }
+
+func (q effectiveAreaQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index, q[j].index = i, j
+}
+
+func (q *effectiveAreaQueue) Push(x any) {
+	item := x.(*effectiveArea)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *effectiveAreaQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return item
+}
+
+// crossesParts reports whether the segment replacing vertices between start
+// and end of the part crosses current segments of parts. Touching at the
+// segment ends is allowed
+func crossesParts(parts []*simplifiedPart, part *simplifiedPart, start, end int) bool {
+	s := segment{start: part.coord(start), end: part.coord(end)}
+
+	minX, maxX := math.Min(s.start[0], s.end[0]), math.Max(s.start[0], s.end[0])
+	minY, maxY := math.Min(s.start[1], s.end[1]), math.Max(s.start[1], s.end[1])
+
+	for _, other := range parts {
+		from := -1
+
+		for i, keep := range other.keep {
+			if !keep {
+				continue
+			}
+
+			j := from
+			from = i
+
+			if j == -1 || other == part && j >= start && i <= end {
+				continue
+			}
+
+			o := segment{start: other.coord(j), end: other.coord(i)}
+			if math.Max(o.start[0], o.end[0]) < minX || math.Min(o.start[0], o.end[0]) > maxX ||
+				math.Max(o.start[1], o.end[1]) < minY || math.Min(o.start[1], o.end[1]) > maxY {
+				continue
+			}
+
+			r := intersect(s, o)
+
+			switch {
+			case r.kind == noIntersection:
+			case r.kind == collinearIntersection:
+				return true
+			case !r.at.Equal(geom.XY, s.start) && !r.at.Equal(geom.XY, s.end):
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// collectParts appends lines and rings of geometry in order of buildSimplified
+func collectParts(g geom.T, parts []*simplifiedPart) []*simplifiedPart {
+	switch g := g.(type) {
+	case *geom.LineString:
+		parts = append(parts, newSimplifiedPart(g.FlatCoords(), g.Stride(), false))
+	case *geom.MultiLineString:
+		for i := 0; i < g.NumLineStrings(); i++ {
+			parts = append(parts, newSimplifiedPart(g.LineString(i).FlatCoords(), g.Stride(), false))
+		}
+	case *geom.Polygon:
+		for i := 0; i < g.NumLinearRings(); i++ {
+			parts = append(parts, newSimplifiedPart(g.LinearRing(i).FlatCoords(), g.Stride(), true))
+		}
+	case *geom.MultiPolygon:
+		for i := 0; i < g.NumPolygons(); i++ {
+			parts = collectParts(g.Polygon(i), parts)
+		}
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			parts = collectParts(child, parts)
+		}
+	}
+
+	return parts
+}
+
+// buildSimplified returns geometry of kept vertices consuming parts collected by collectParts
+func buildSimplified(g geom.T, parts *[]*simplifiedPart) geom.T {
+	layout := g.Layout()
+
+	next := func() []float64 {
+		part := (*parts)[0]
+		*parts = (*parts)[1:]
+
+		flatCoords := make([]float64, 0, part.kept*part.stride)
+		for i, keep := range part.keep {
+			if keep {
+				flatCoords = append(flatCoords, part.flatCoords[i*part.stride:(i+1)*part.stride]...)
+			}
+		}
+
+		return flatCoords
+	}
+
+	switch g := g.(type) {
+	case *geom.LineString:
+		return geom.NewLineStringFlat(layout, next()).SetSRID(g.SRID())
+	case *geom.MultiLineString:
+		mls := geom.NewMultiLineString(layout).SetSRID(g.SRID())
+
+		for i := 0; i < g.NumLineStrings(); i++ {
+			_ = mls.Push(geom.NewLineStringFlat(layout, next()))
+		}
+
+		return mls
+	case *geom.Polygon:
+		return buildSimplifiedPolygon(g, next).SetSRID(g.SRID())
+	case *geom.MultiPolygon:
+		mp := geom.NewMultiPolygon(layout).SetSRID(g.SRID())
+
+		for i := 0; i < g.NumPolygons(); i++ {
+			if p := buildSimplifiedPolygon(g.Polygon(i), next); !p.Empty() {
+				_ = mp.Push(p)
+			}
+		}
+
+		return mp
+	case *geom.GeometryCollection:
+		gc := geom.NewGeometryCollection().SetSRID(g.SRID())
+
+		for _, child := range g.Geoms() {
+			_ = gc.Push(buildSimplified(child, parts))
+		}
+
+		return gc
+	default:
+		return g
+	}
+}
+
+func buildSimplifiedPolygon(p *geom.Polygon, next func() []float64) *geom.Polygon {
+	var (
+		layout = p.Layout()
+		result = geom.NewPolygon(layout)
+		shell  = true
+	)
+
+	for i := 0; i < p.NumLinearRings(); i++ {
+		// all rings are consumed even when the shell collapsed
+		flatCoords := next()
+
+		if len(flatCoords) < 4*p.Stride() {
+			if i == 0 {
+				shell = false
+			}
+
+			continue
+		}
+
+		if shell {
+			_ = result.Push(geom.NewLinearRingFlat(layout, flatCoords))
+		}
+	}
+
+	return result
+}
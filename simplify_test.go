@@ -0,0 +1,151 @@
+package georm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestGeometrySimplify(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Geom      geom.T
+		Tolerance float64
+		Expect    geom.T
+		Topology  geom.T
+	}{
+		{
+			Name:      "nil",
+			Geom:      nil,
+			Tolerance: 1,
+			Expect:    nil,
+			Topology:  nil,
+		},
+		{
+			Name:      "point is returned as is",
+			Geom:      geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 2}),
+			Tolerance: 10,
+			Expect:    geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 2}),
+			Topology:  geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 2}),
+		},
+		{
+			Name:      "line string",
+			Geom:      geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 0.1}, {2, -0.1}, {3, 0}}).SetSRID(4326),
+			Tolerance: 0.5,
+			Expect:    geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {3, 0}}).SetSRID(4326),
+			Topology:  geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {3, 0}}).SetSRID(4326),
+		},
+		{
+			Name:      "line string with spike",
+			Geom:      geom.NewLineString(geom.XYZ).MustSetCoords([]geom.Coord{{0, 0, 1}, {1, 0, 2}, {2, 3, 3}, {3, 0, 4}, {4, 0, 5}}),
+			Tolerance: 1,
+			Expect:    geom.NewLineString(geom.XYZ).MustSetCoords([]geom.Coord{{0, 0, 1}, {2, 3, 3}, {4, 0, 5}}),
+			Topology:  geom.NewLineString(geom.XYZ).MustSetCoords([]geom.Coord{{0, 0, 1}, {2, 3, 3}, {4, 0, 5}}),
+		},
+		{
+			Name: "lines would cross",
+			Geom: geom.NewMultiLineString(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {5, 2}, {10, 0}},
+				{{5, 1}, {5, -1}},
+			}),
+			Tolerance: 3,
+			Expect: geom.NewMultiLineString(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10, 0}},
+				{{5, 1}, {5, -1}},
+			}),
+			Topology: geom.NewMultiLineString(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {5, 2}, {10, 0}},
+				{{5, 1}, {5, -1}},
+			}),
+		},
+		{
+			Name:      "polygon",
+			Geom:      geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {5, 0.1}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}),
+			Tolerance: 0.5,
+			Expect:    geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}),
+			Topology:  geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}),
+		},
+		{
+			Name:      "collapsed polygon",
+			Geom:      geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {0, 1}, {0, 0}}}),
+			Tolerance: 2,
+			Expect:    geom.NewPolygon(geom.XY),
+			Topology:  geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {0, 1}, {0, 0}}}),
+		},
+		{
+			Name: "hole would cross shell",
+			Geom: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10, 0}, {10, 10}, {5, 12}, {0, 10}, {0, 0}},
+				{{4, 9}, {6, 9}, {6, 11}, {4, 11}, {4, 9}},
+			}),
+			Tolerance: 3,
+			Expect:    geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}),
+			Topology: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10, 0}, {10, 10}, {5, 12}, {0, 10}, {0, 0}},
+				{{4, 9}, {6, 9}, {6, 11}, {4, 9}},
+			}),
+		},
+		{
+			Name: "multi polygon with collapsed polygon",
+			Geom: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {10, 0}, {10, 10}, {0, 0}}},
+				{{{20, 20}, {20.1, 20}, {20.1, 20.1}, {20, 20}}},
+			}),
+			Tolerance: 1,
+			Expect: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {10, 0}, {10, 10}, {0, 0}}},
+			}),
+			Topology: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {10, 0}, {10, 10}, {0, 0}}},
+				{{{20, 20}, {20.1, 20}, {20.1, 20.1}, {20, 20}}},
+			}),
+		},
+		{
+			Name: "geometry collection",
+			Geom: geom.NewGeometryCollection().MustPush(
+				geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 1}),
+				geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 0.1}, {2, 0}}),
+			),
+			Tolerance: 1,
+			Expect: geom.NewGeometryCollection().MustPush(
+				geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 1}),
+				geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {2, 0}}),
+			),
+			Topology: geom.NewGeometryCollection().MustPush(
+				geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 1}),
+				geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {2, 0}}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expect, New(test.Geom).Simplify(test.Tolerance).Geom)
+
+			simplified := New(test.Geom).SimplifyPreserveTopology(test.Tolerance)
+			assert.Equal(t, test.Topology, simplified.Geom)
+
+			if test.Geom != nil && New(test.Geom).IsValid() {
+				assert.True(t, simplified.IsValid())
+			}
+		})
+	}
+}
+
+func TestGeometrySimplifyVW(t *testing.T) {
+	line := NewLineString(geom.Coord{0, 0}, geom.Coord{1, 0.1}, geom.Coord{2, 0}, geom.Coord{3, 3}, geom.Coord{4, 0})
+
+	assert.Equal(t, []geom.Coord{{0, 0}, {2, 0}, {3, 3}, {4, 0}}, line.SimplifyVW(0.5).Coords())
+	assert.Equal(t, []geom.Coord{{0, 0}, {4, 0}}, line.SimplifyVW(10).Coords())
+
+	polygon := NewPolygon([]geom.Coord{{0, 0}, {5, 0.1}, {10, 0}, {10, 10}, {0, 10}, {0, 0}})
+
+	simplified := polygon.SimplifyVW(1)
+	require.Equal(t, 5, simplified.Geom.NumCoords())
+	assert.InDelta(t, 100, simplified.Area(), 1e-9)
+
+	// rings keep at least four points
+	assert.Equal(t, 4, polygon.SimplifyVW(1000).Geom.NumCoords())
+}
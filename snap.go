@@ -0,0 +1,128 @@
+package georm
+
+import (
+	"math"
+
+	"github.com/twpayne/go-geom"
+)
+
+// SnapToGrid rounds x and y of all points to the given number of decimal places
+// and removes consecutive duplicate points that appear after rounding.
+// Lines collapsed to a single point and rings collapsed to less than
+// four points are removed, collapsed Polygon shell makes the polygon empty
+func (g Geometry[T]) SnapToGrid(precision int) Geometry[T] {
+	if isNil(g.Geom) {
+		return g
+	}
+
+	snapped, ok := snapToGrid(g.Geom, math.Pow10(precision)).(T)
+	if !ok {
+		return g
+	}
+
+	return Geometry[T]{snapped}
+}
+
+// snapToGrid returns SnapToGrid result as any, used by Plugin
+// for geometry fields of any type
+func (g Geometry[T]) snapToGrid(precision int) any {
+	return g.SnapToGrid(precision)
+}
+
+type gridSnapper interface {
+	snapToGrid(precision int) any
+}
+
+func snapToGrid(g geom.T, scale float64) geom.T {
+	layout, stride := g.Layout(), g.Stride()
+
+	switch g := g.(type) {
+	case *geom.Point:
+		return geom.NewPointFlat(layout, snapCoords(g.FlatCoords(), stride, scale, false)).SetSRID(g.SRID())
+	case *geom.MultiPoint:
+		return geom.NewMultiPointFlat(layout, snapCoords(g.FlatCoords(), stride, scale, false)).SetSRID(g.SRID())
+	case *geom.LineString:
+		flatCoords := snapCoords(g.FlatCoords(), stride, scale, true)
+		if len(flatCoords) < 2*stride {
+			flatCoords = nil
+		}
+
+		return geom.NewLineStringFlat(layout, flatCoords).SetSRID(g.SRID())
+	case *geom.MultiLineString:
+		mls := geom.NewMultiLineString(layout).SetSRID(g.SRID())
+
+		for i := 0; i < g.NumLineStrings(); i++ {
+			flatCoords := snapCoords(g.LineString(i).FlatCoords(), stride, scale, true)
+			if len(flatCoords) >= 2*stride {
+				_ = mls.Push(geom.NewLineStringFlat(layout, flatCoords))
+			}
+		}
+
+		return mls
+	case *geom.Polygon:
+		return snapPolygon(g, scale).SetSRID(g.SRID())
+	case *geom.MultiPolygon:
+		mp := geom.NewMultiPolygon(layout).SetSRID(g.SRID())
+
+		for i := 0; i < g.NumPolygons(); i++ {
+			if p := snapPolygon(g.Polygon(i), scale); !p.Empty() {
+				_ = mp.Push(p)
+			}
+		}
+
+		return mp
+	case *geom.GeometryCollection:
+		gc := geom.NewGeometryCollection().SetSRID(g.SRID())
+
+		for _, child := range g.Geoms() {
+			_ = gc.Push(snapToGrid(child, scale))
+		}
+
+		return gc
+	default:
+		return g
+	}
+}
+
+func snapPolygon(p *geom.Polygon, scale float64) *geom.Polygon {
+	var (
+		layout = p.Layout()
+		stride = p.Stride()
+		result = geom.NewPolygon(layout)
+	)
+
+	for i := 0; i < p.NumLinearRings(); i++ {
+		flatCoords := snapCoords(p.LinearRing(i).FlatCoords(), stride, scale, true)
+
+		if len(flatCoords) < 4*stride {
+			if i == 0 {
+				return result
+			}
+
+			continue
+		}
+
+		_ = result.Push(geom.NewLinearRingFlat(layout, flatCoords))
+	}
+
+	return result
+}
+
+// snapCoords returns flat coordinates with x and y rounded by scale,
+// optionally removing consecutive duplicates
+func snapCoords(flatCoords []float64, stride int, scale float64, dedupe bool) []float64 {
+	result := make([]float64, 0, len(flatCoords))
+
+	for i := 0; i < len(flatCoords); i += stride {
+		x, y := math.Round(flatCoords[i]*scale)/scale, math.Round(flatCoords[i+1]*scale)/scale
+
+		if n := len(result); dedupe && n > 0 && result[n-stride] == x && result[n-stride+1] == y {
+			continue
+		}
+
+		result = append(result, x, y)
+		result = append(result, flatCoords[i+2:i+stride]...)
+	}
+
+	return result
+}
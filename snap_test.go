@@ -0,0 +1,82 @@
+package georm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twpayne/go-geom"
+)
+
+func TestGeometrySnapToGrid(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Geom      geom.T
+		Precision int
+		Expect    geom.T
+	}{
+		{
+			Name:      "nil",
+			Geom:      nil,
+			Precision: 7,
+			Expect:    nil,
+		},
+		{
+			Name:      "point",
+			Geom:      geom.NewPoint(geom.XYZ).MustSetCoords(geom.Coord{37.617634567890123, 55.755825678901234, 144.123456}).SetSRID(4326),
+			Precision: 7,
+			Expect:    geom.NewPoint(geom.XYZ).MustSetCoords(geom.Coord{37.6176346, 55.7558257, 144.123456}).SetSRID(4326),
+		},
+		{
+			Name:      "negative precision",
+			Geom:      geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1234, 5678}),
+			Precision: -2,
+			Expect:    geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1200, 5700}),
+		},
+		{
+			Name:      "line string duplicates",
+			Geom:      geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0.01, 0.01}, {0.011, 0.012}, {1.004, 1.001}}),
+			Precision: 2,
+			Expect:    geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0.01, 0.01}, {1, 1}}),
+		},
+		{
+			Name:      "collapsed line string",
+			Geom:      geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0.01, 0.01}, {0.011, 0.012}}),
+			Precision: 2,
+			Expect:    geom.NewLineString(geom.XY),
+		},
+		{
+			Name: "polygon with collapsed hole",
+			Geom: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10.001, 0}, {10, 10}, {0, 10}, {0, 0}},
+				{{5, 5}, {5.001, 5}, {5.001, 5.001}, {5, 5}},
+			}),
+			Precision: 1,
+			Expect:    geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}),
+		},
+		{
+			Name: "multi polygon with collapsed polygon",
+			Geom: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+				{{{5, 5}, {5.001, 5}, {5.001, 5.001}, {5, 5}}},
+			}),
+			Precision: 1,
+			Expect:    geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}),
+		},
+		{
+			Name: "geometry collection",
+			Geom: geom.NewGeometryCollection().MustPush(
+				geom.NewMultiPoint(geom.XY).MustSetCoords([]geom.Coord{{0.04, 0.04}, {0.01, 0.01}}),
+			),
+			Precision: 1,
+			Expect: geom.NewGeometryCollection().MustPush(
+				geom.NewMultiPoint(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {0, 0}}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expect, New(test.Geom).SnapToGrid(test.Precision).Geom)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package georm
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/twpayne/go-geom"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// ChangeTolerance is the default tolerance of Equal for change detection
+// of geometry fields in Save and Updates, the tolerance=X tag overrides it
+var ChangeTolerance = 1e-9
+
+// Snapshot keeps geometry fields of a model as they were loaded from or
+// written to the database. Embed it into a model to skip writing unchanged
+// geometries in Save and Updates of the same model, Plugin fills it:
+//
+//	type Address struct {
+//		georm.Snapshot
+//
+//		ID       uint
+//		Location georm.Point
+//	}
+//
+// Without Snapshot geometries are compared only with the model of
+// db.Model(&loaded).Updates(values). Unchanged fields are omitted from
+// the update, so gorm Statement.Changed reports them as unchanged too
+type Snapshot struct {
+	geometries map[string]geom.T
+}
+
+func (s *Snapshot) geometrySnapshot() *Snapshot { return s }
+
+type snapshotter interface {
+	geometrySnapshot() *Snapshot
+}
+
+// snapshotSettingsKey stores snapshotUpdate in Statement.Settings
+const snapshotSettingsKey = "georm:snapshot"
+
+// snapshotUpdate keeps Statement.Omits before unchanged fields were added
+// and geometries to store in the snapshot after the update
+type snapshotUpdate struct {
+	omits   []string
+	written map[string]geom.T
+}
+
+var geometricType = reflect.TypeOf((*Geometric)(nil)).Elem()
+
+func isGeometryField(field *schema.Field) bool {
+	return field.DBName != "" && field.IndirectFieldType.Implements(geometricType)
+}
+
+// geometryOf returns geometry of a field value, nil pointers are nil geometries
+func geometryOf(value any) (geom.T, bool) {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() || !rv.Type().Implements(geometricType) {
+		return nil, false
+	}
+
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil, true
+	}
+
+	return value.(Geometric).GeomT(), true
+}
+
+func fieldTolerance(field *schema.Field) float64 {
+	if tolerance, err := strconv.ParseFloat(TagSettings(field)["TOLERANCE"], 64); err == nil {
+		return tolerance
+	}
+
+	return ChangeTolerance
+}
+
+// takeSnapshot stores geometry fields of a model or a slice of models
+func takeSnapshot(db *gorm.DB, rv reflect.Value) {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			takeSnapshot(db, reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		if rv.Type() != db.Statement.Schema.ModelType || !rv.CanAddr() {
+			return
+		}
+
+		s, ok := rv.Addr().Interface().(snapshotter)
+		if !ok {
+			return
+		}
+
+		geometries := map[string]geom.T{}
+
+		for _, field := range db.Statement.Schema.Fields {
+			if !isGeometryField(field) {
+				continue
+			}
+
+			if geometry, ok := geometryOf(field.ReflectValueOf(db.Statement.Context, rv).Interface()); ok {
+				geometries[field.Name] = cloneGeometry(geometry)
+			}
+		}
+
+		s.geometrySnapshot().geometries = geometries
+	}
+}
+
+// updatingGeometry returns new value of the field in Updates or Save
+func updatingGeometry(db *gorm.DB, field *schema.Field) (geom.T, bool) {
+	stmt := db.Statement
+
+	if values, ok := stmt.Dest.(map[string]interface{}); ok {
+		value, ok := values[field.Name]
+		if !ok {
+			if value, ok = values[field.DBName]; !ok {
+				return nil, false
+			}
+		}
+
+		return geometryOf(value)
+	}
+
+	destValue := reflect.Indirect(reflect.ValueOf(stmt.Dest))
+	if !destValue.IsValid() || destValue.Type() != stmt.Schema.ModelType {
+		return nil, false
+	}
+
+	return geometryOf(field.ReflectValueOf(stmt.Context, destValue).Interface())
+}
+
+// omitUnchanged omits geometry fields equal to the snapshot or to the model
+// updated with other values before update hooks, so hooks see them unchanged
+func omitUnchanged(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil {
+		return
+	}
+
+	model := stmt.ReflectValue
+	if model.Kind() != reflect.Struct || model.Type() != stmt.Schema.ModelType || !model.CanAddr() {
+		return
+	}
+
+	var snapshot *Snapshot
+	if s, ok := model.Addr().Interface().(snapshotter); ok {
+		snapshot = s.geometrySnapshot()
+	}
+
+	update := snapshotUpdate{omits: stmt.Omits, written: map[string]geom.T{}}
+
+	for _, field := range stmt.Schema.Fields {
+		if !isGeometryField(field) {
+			continue
+		}
+
+		value, ok := updatingGeometry(db, field)
+		if !ok {
+			continue
+		}
+
+		if precision, ok := fieldPrecision(field); ok && value != nil {
+			value = New(value).SnapToGrid(precision).Geom
+		}
+
+		var loaded geom.T
+
+		switch {
+		case snapshot != nil && snapshot.geometries != nil:
+			if loaded, ok = snapshot.geometries[field.Name]; !ok {
+				continue
+			}
+		case stmt.Dest != stmt.Model:
+			// zero model value is not loaded
+			if loaded, ok = geometryOf(field.ReflectValueOf(stmt.Context, model).Interface()); !ok || loaded == nil {
+				continue
+			}
+		default:
+			continue
+		}
+
+		if New(value).Equal(New(loaded), fieldTolerance(field)) {
+			stmt.Omits = append(slices.Clip(stmt.Omits), field.Name)
+			continue
+		}
+
+		if _, ok := stmt.Dest.(map[string]interface{}); ok || value != nil {
+			update.written[field.Name] = value
+		}
+	}
+
+	stmt.Settings.Store(snapshotSettingsKey, update)
+}
+
+// afterUpdate restores omitted fields for callbacks after the update
+// and stores written geometries in the snapshot
+func afterUpdate(db *gorm.DB) {
+	stmt := db.Statement
+
+	value, ok := stmt.Settings.LoadAndDelete(snapshotSettingsKey)
+	if !ok {
+		return
+	}
+
+	update := value.(snapshotUpdate)
+	stmt.Omits = update.omits
+
+	if db.Error != nil {
+		return
+	}
+
+	if s, ok := stmt.ReflectValue.Addr().Interface().(snapshotter); ok && s.geometrySnapshot().geometries != nil {
+		for name, geometry := range update.written {
+			s.geometrySnapshot().geometries[name] = cloneGeometry(geometry)
+		}
+	}
+}
+
+func afterCreate(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+
+	takeSnapshot(db, db.Statement.ReflectValue)
+}
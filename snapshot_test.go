@@ -0,0 +1,146 @@
+package georm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+	"gorm.io/gorm"
+)
+
+type testHome struct {
+	Snapshot
+
+	ID       uint `gorm:"primaryKey"`
+	Name     string
+	Location Point
+	Area     *Polygon `georm:"tolerance=0.01"`
+
+	locationChanged bool
+}
+
+func (a *testHome) BeforeUpdate(tx *gorm.DB) error {
+	a.locationChanged = tx.Statement.Changed("Location")
+	return nil
+}
+
+func TestPluginSnapshotSave(t *testing.T) {
+	db := pluginDB(t)
+
+	// dry run does not scan rows, dest values are the loaded ones
+	load := func(t *testing.T) *testHome {
+		area := NewPolygon([]geom.Coord{{0, 0}, {1, 0}, {1, 1}, {0, 0}})
+
+		home := &testHome{ID: 1, Name: "home", Location: NewPoint(37.6176, 55.7558), Area: &area}
+		require.NoError(t, db.Find(home).Error)
+
+		return home
+	}
+
+	tests := []struct {
+		Name   string
+		Input  func(t *testing.T, home *testHome)
+		Expect string
+	}{
+		{
+			Name: "float noise",
+			Input: func(t *testing.T, home *testHome) {
+				home.Name, home.Location = "work", NewPoint(37.6176+1e-12, 55.7558)
+			},
+			Expect: `UPDATE "test_homes" SET "name"='work' WHERE "id" = 1`,
+		},
+		{
+			Name: "within field tolerance",
+			Input: func(t *testing.T, home *testHome) {
+				moved := NewPolygon([]geom.Coord{{0, 0}, {1, 0}, {1, 1.005}, {0, 0}})
+				home.Area = &moved
+			},
+			Expect: `UPDATE "test_homes" SET "name"='home' WHERE "id" = 1`,
+		},
+		{
+			Name:   "moved point",
+			Input:  func(t *testing.T, home *testHome) { home.Location = NewPoint(37.6, 55.7) },
+			Expect: `UPDATE "test_homes" SET "name"='home',"location"='0101000020e6100000cdcccccccccc42409a99999999d94b40' WHERE "id" = 1`,
+		},
+		{
+			Name: "snapshot is updated",
+			Input: func(t *testing.T, home *testHome) {
+				home.Location = NewPoint(37.6, 55.7)
+				require.NoError(t, db.Save(home).Error)
+			},
+			Expect: `UPDATE "test_homes" SET "name"='home' WHERE "id" = 1`,
+		},
+		{
+			Name:   "removed area",
+			Input:  func(t *testing.T, home *testHome) { home.Area = nil },
+			Expect: `UPDATE "test_homes" SET "name"='home',"area"=NULL WHERE "id" = 1`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			home := load(t)
+			test.Input(t, home)
+
+			tx := db.Save(home)
+			require.NoError(t, tx.Error)
+
+			assert.Equal(t, test.Expect, db.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...))
+			assert.Empty(t, tx.Statement.Omits)
+		})
+	}
+}
+
+func TestPluginSnapshotUpdates(t *testing.T) {
+	db := pluginDB(t)
+
+	// without snapshot the model is compared with other values
+	loaded := testTrack{ID: 1, Start: NewPoint(1, 2), Original: NewPoint(3, 4)}
+
+	tx := db.Model(&loaded).Updates(map[string]interface{}{"start": NewPoint(1.0001, 2), "original": NewPoint(3, 4)})
+	require.NoError(t, tx.Error)
+	assert.NotContains(t, tx.Statement.SQL.String(), `"start"`)
+	assert.NotContains(t, tx.Statement.SQL.String(), `"original"`)
+
+	tx = db.Model(&loaded).Updates(&testTrack{Start: NewPoint(1, 2), Original: NewPoint(5, 6)})
+	require.NoError(t, tx.Error)
+	assert.NotContains(t, tx.Statement.SQL.String(), `"start"`)
+	assert.Contains(t, tx.Statement.SQL.String(), `"original"`)
+
+	// zero model value is not loaded
+	tx = db.Model(&testTrack{ID: 1}).Updates(map[string]interface{}{"start": NewPoint(1, 2)})
+	require.NoError(t, tx.Error)
+	assert.Contains(t, tx.Statement.SQL.String(), `"start"`)
+
+	// the same model without snapshot is always written
+	tx = db.Save(&loaded)
+	require.NoError(t, tx.Error)
+	assert.Contains(t, tx.Statement.SQL.String(), `"start"`)
+}
+
+func TestPluginSnapshotCreate(t *testing.T) {
+	db := pluginDB(t)
+
+	address := testHome{Location: NewPoint(37.6176, 55.7558)}
+	require.NoError(t, db.Create(&address).Error)
+
+	address.ID, address.Name = 1, "home"
+
+	tx := db.Updates(&address)
+	require.NoError(t, tx.Error)
+	assert.NotContains(t, tx.Statement.SQL.String(), `"location"`)
+}
+
+func TestPluginSnapshotChanged(t *testing.T) {
+	db := pluginDB(t)
+
+	loaded := testHome{ID: 1, Location: NewPoint(37.6176, 55.7558)}
+
+	// gorm Statement.Changed in hooks compares values with the model
+	require.NoError(t, db.Model(&loaded).Updates(&testHome{Name: "home", Location: NewPoint(37.6176+1e-12, 55.7558)}).Error)
+	assert.False(t, loaded.locationChanged)
+
+	require.NoError(t, db.Model(&loaded).Updates(&testHome{Location: NewPoint(37.6, 55.7)}).Error)
+	assert.True(t, loaded.locationChanged)
+}
@@ -44,6 +44,10 @@ func (g *Geometry[T]) Scan(value interface{}) (err error) {
 
 	switch v := value.(type) {
 	case string:
+		if err = checkBytes(len(v) / 2); err != nil {
+			return err
+		}
+
 		wkb, err = hex.DecodeString(v)
 	case []byte:
 		wkb = v
@@ -55,6 +59,10 @@ func (g *Geometry[T]) Scan(value interface{}) (err error) {
 		return err
 	}
 
+	if err = checkWKB(wkb); err != nil {
+		return err
+	}
+
 	geometryT, err := ewkb.Unmarshal(wkb)
 	if err != nil {
 		return err
@@ -74,11 +82,49 @@ func (g Geometry[T]) Value() (driver.Value, error) {
 		return nil, nil
 	}
 
+	if err := checkSize(g.Geom); err != nil {
+		return nil, err
+	}
+
+	if SplitAntimeridianOnWrite && checkGeographic(g.Geom) == nil {
+		split, err := g.SplitAntimeridian()
+		if err != nil {
+			return nil, err
+		}
+
+		g = split
+	}
+
+	if err := checkCoords(g.Geom); err != nil {
+		return nil, err
+	}
+
+	if MakeValidOnWrite {
+		fixed, err := g.MakeValid()
+		if err != nil {
+			return nil, err
+		}
+
+		g = fixed
+	}
+
+	if ValidateOnWrite {
+		if err := g.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	g = g.orient(OrientationOnWrite)
+
 	sb := &bytes.Buffer{}
 	if err := ewkb.Write(sb, binary.LittleEndian, g.Geom); err != nil {
 		return nil, err
 	}
 
+	if err := checkBytes(sb.Len()); err != nil {
+		return nil, err
+	}
+
 	return hex.EncodeToString(sb.Bytes()), nil
 }
 
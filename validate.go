@@ -0,0 +1,363 @@
+package georm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/xy"
+	"github.com/twpayne/go-geom/xy/location"
+)
+
+var ErrInvalidGeometry = errors.New("invalid geometry")
+
+// ValidateOnWrite enables validation of geometries in Value,
+// invalid geometries are rejected with ValidationError
+var ValidateOnWrite = false
+
+// Validation reasons, named as in PostGIS ST_IsValidReason
+const (
+	ReasonInvalidCoordinate    = "Invalid Coordinate"
+	ReasonTooFewPoints         = "Too few points"
+	ReasonRingNotClosed        = "Ring not closed"
+	ReasonRingSelfIntersection = "Ring Self-intersection"
+	ReasonSelfIntersection     = "Self-intersection"
+	ReasonDisconnectedInterior = "Interior is disconnected"
+	ReasonHoleOutsideShell     = "Hole lies outside shell"
+	ReasonNestedHoles          = "Holes are nested"
+	ReasonNestedShells         = "Nested shells"
+)
+
+// ValidationError describes why geometry is not valid by OGC simple features rules
+type ValidationError struct {
+	Reason string
+
+	// Location of the problem, nil when it cannot be pointed
+	Location geom.Coord
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Location) < 2 {
+		return "invalid geometry: " + e.Reason
+	}
+
+	return fmt.Sprintf("invalid geometry: %s [%g %g]", e.Reason, e.Location[0], e.Location[1])
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidGeometry
+}
+
+func invalid(reason string, location geom.Coord) *ValidationError {
+	return &ValidationError{Reason: reason, Location: slices.Clone(location)}
+}
+
+// Validate checks geometry by OGC simple features rules, returns *ValidationError
+// for invalid geometries. Empty geometries are valid
+func (g Geometry[T]) Validate() error {
+	return validate(g.Geom)
+}
+
+// IsValid reports whether geometry is valid by OGC simple features rules
+func (g Geometry[T]) IsValid() bool {
+	return validate(g.Geom) == nil
+}
+
+func validate(g geom.T) error {
+	if isNil(g) {
+		return nil
+	}
+
+	if collection, ok := g.(*geom.GeometryCollection); ok {
+		for _, child := range collection.Geoms() {
+			if err := validate(child); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := validateCoords(g.FlatCoords(), g.Stride()); err != nil {
+		return err
+	}
+
+	switch g := g.(type) {
+	case *geom.LineString:
+		return validateLine(g.FlatCoords(), g.Stride())
+	case *geom.MultiLineString:
+		for i := 0; i < g.NumLineStrings(); i++ {
+			if err := validateLine(g.LineString(i).FlatCoords(), g.Stride()); err != nil {
+				return err
+			}
+		}
+	case *geom.Polygon:
+		return validatePolygon(g)
+	case *geom.MultiPolygon:
+		for i := 0; i < g.NumPolygons(); i++ {
+			if err := validatePolygon(g.Polygon(i)); err != nil {
+				return err
+			}
+		}
+
+		return validateMultiPolygon(g)
+	}
+
+	return nil
+}
+
+func validateCoords(flatCoords []float64, stride int) error {
+	for i, value := range flatCoords {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			start := i - i%stride
+			return invalid(ReasonInvalidCoordinate, flatCoords[start:start+stride])
+		}
+	}
+
+	return nil
+}
+
+func validateLine(flatCoords []float64, stride int) error {
+	if len(flatCoords) == 0 {
+		return nil
+	}
+
+	if countDistinct(flatCoords, stride) < 2 {
+		return invalid(ReasonTooFewPoints, flatCoords[:stride])
+	}
+
+	return nil
+}
+
+// countDistinct counts points ignoring consecutive repeated ones
+func countDistinct(flatCoords []float64, stride int) int {
+	var count int
+
+	for i := 0; i < len(flatCoords); i += stride {
+		if i == 0 || flatCoords[i] != flatCoords[i-stride] || flatCoords[i+1] != flatCoords[i-stride+1] {
+			count++
+		}
+	}
+
+	return count
+}
+
+func validateRing(flatCoords []float64, stride int) error {
+	n := len(flatCoords) / stride
+
+	if n < 4 {
+		if n == 0 {
+			return invalid(ReasonTooFewPoints, nil)
+		}
+
+		return invalid(ReasonTooFewPoints, flatCoords[:stride])
+	}
+
+	if flatCoords[0] != flatCoords[len(flatCoords)-stride] || flatCoords[1] != flatCoords[len(flatCoords)-stride+1] {
+		return invalid(ReasonRingNotClosed, flatCoords[:stride])
+	}
+
+	// closing point is repeated first one
+	if countDistinct(flatCoords, stride)-1 < 3 {
+		return invalid(ReasonTooFewPoints, flatCoords[:stride])
+	}
+
+	segments := ringSegments(flatCoords, stride)
+
+	var err error
+
+	segmentPairs(segments, nil, func(i, j int) bool {
+		adjacent := j == i+1 || (i == 0 && j == len(segments)-1)
+
+		r := intersect(segments[i], segments[j])
+
+		switch {
+		case r.kind == noIntersection:
+			return true
+		case r.kind == collinearIntersection || r.proper:
+			err = invalid(ReasonSelfIntersection, r.at)
+		case !adjacent:
+			err = invalid(ReasonRingSelfIntersection, r.at)
+		}
+
+		return err == nil
+	})
+
+	return err
+}
+
+func validatePolygon(p *geom.Polygon) error {
+	var (
+		layout = p.Layout()
+		stride = p.Stride()
+		rings  = make([][]float64, p.NumLinearRings())
+	)
+
+	for i := range rings {
+		rings[i] = p.LinearRing(i).FlatCoords()
+
+		if err := validateRing(rings[i], stride); err != nil {
+			return err
+		}
+	}
+
+	// rings may touch each other at a single point only
+	for i := range rings {
+		for j := i + 1; j < len(rings); j++ {
+			if err := validateRingsTouch(rings[i], rings[j], stride); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(rings) < 2 {
+		return nil
+	}
+
+	for i, hole := range rings[1:] {
+		c, loc := locateRing(layout, hole, rings[0])
+		if loc == location.Exterior {
+			return invalid(ReasonHoleOutsideShell, c)
+		}
+
+		for _, other := range rings[i+2:] {
+			if c, loc = locateRing(layout, hole, other); loc == location.Interior {
+				return invalid(ReasonNestedHoles, c)
+			}
+
+			if c, loc = locateRing(layout, other, hole); loc == location.Interior {
+				return invalid(ReasonNestedHoles, c)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateRingsTouch checks that rings neither cross nor overlap and touch
+// at most at a single point, which keeps polygon interior connected
+func validateRingsTouch(ring1, ring2 []float64, stride int) error {
+	var (
+		segments1 = ringSegments(ring1, stride)
+		segments2 = ringSegments(ring2, stride)
+
+		touch geom.Coord
+		err   error
+	)
+
+	segmentPairs(segments1, segments2, func(i, j int) bool {
+		r := intersect(segments1[i], segments2[j])
+
+		switch {
+		case r.kind == noIntersection:
+			return true
+		case r.kind == collinearIntersection || r.proper:
+			err = invalid(ReasonSelfIntersection, r.at)
+		case touch == nil:
+			touch = r.at
+		case !touch.Equal(geom.XY, r.at):
+			err = invalid(ReasonDisconnectedInterior, r.at)
+		}
+
+		return err == nil
+	})
+
+	return err
+}
+
+// locateRing locates the ring relative to the other ring by the first vertex
+// not lying on the other ring boundary, rings must not cross each other
+func locateRing(layout geom.Layout, ring, other []float64) (geom.Coord, location.Type) {
+	stride := layout.Stride()
+
+	for i := 0; i < len(ring); i += stride {
+		c := geom.Coord(ring[i : i+stride])
+
+		if loc := xy.LocatePointInRing(layout, c, other); loc != location.Boundary {
+			return c, loc
+		}
+	}
+
+	return nil, location.Boundary
+}
+
+func validateMultiPolygon(mp *geom.MultiPolygon) error {
+	var (
+		layout   = mp.Layout()
+		polygons = make([]*geom.Polygon, mp.NumPolygons())
+	)
+
+	for i := range polygons {
+		polygons[i] = mp.Polygon(i)
+	}
+
+	for i, p1 := range polygons {
+		for _, p2 := range polygons[i+1:] {
+			if p1.Empty() || p2.Empty() || !p1.Bounds().Overlaps(layout, p2.Bounds()) {
+				continue
+			}
+
+			if err := validatePolygonsDisjoint(layout, p1, p2); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validatePolygonsDisjoint checks that interiors of polygons do not intersect
+func validatePolygonsDisjoint(layout geom.Layout, p1, p2 *geom.Polygon) error {
+	stride := layout.Stride()
+
+	for i := 0; i < p1.NumLinearRings(); i++ {
+		for j := 0; j < p2.NumLinearRings(); j++ {
+			var (
+				segments1 = ringSegments(p1.LinearRing(i).FlatCoords(), stride)
+				segments2 = ringSegments(p2.LinearRing(j).FlatCoords(), stride)
+
+				err error
+			)
+
+			segmentPairs(segments1, segments2, func(i, j int) bool {
+				r := intersect(segments1[i], segments2[j])
+				if r.kind == collinearIntersection || r.proper {
+					err = invalid(ReasonSelfIntersection, r.at)
+				}
+
+				return err == nil
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, pair := range [2][2]*geom.Polygon{{p1, p2}, {p2, p1}} {
+		inner, outer := pair[0], pair[1]
+
+		c, loc := locateRing(layout, inner.LinearRing(0).FlatCoords(), outer.LinearRing(0).FlatCoords())
+		if loc != location.Interior {
+			continue
+		}
+
+		// shell inside a hole of the outer polygon is an island
+		island := false
+
+		for k := 1; k < outer.NumLinearRings(); k++ {
+			if xy.LocatePointInRing(layout, c, outer.LinearRing(k).FlatCoords()) != location.Exterior {
+				island = true
+				break
+			}
+		}
+
+		if !island {
+			return invalid(ReasonNestedShells, c)
+		}
+	}
+
+	return nil
+}
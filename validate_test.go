@@ -0,0 +1,230 @@
+package georm
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestGeometryValidate(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Geom           geom.T
+		ExpectReason   string
+		ExpectLocation geom.Coord
+	}{
+		{
+			Name: "nil",
+			Geom: nil,
+		},
+		{
+			Name: "point",
+			Geom: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{42, 42}),
+		},
+		{
+			Name:           "point nan",
+			Geom:           geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{math.NaN(), 42}),
+			ExpectReason:   ReasonInvalidCoordinate,
+			ExpectLocation: geom.Coord{math.NaN(), 42},
+		},
+		{
+			Name:           "multi point inf",
+			Geom:           geom.NewMultiPoint(geom.XY).MustSetCoords([]geom.Coord{{1, 1}, {2, math.Inf(1)}}),
+			ExpectReason:   ReasonInvalidCoordinate,
+			ExpectLocation: geom.Coord{2, math.Inf(1)},
+		},
+		{
+			Name: "self-intersecting line string is valid",
+			Geom: geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {2, 2}, {2, 0}, {0, 2}}),
+		},
+		{
+			Name:           "line string with repeated point",
+			Geom:           geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{1, 1}, {1, 1}}),
+			ExpectReason:   ReasonTooFewPoints,
+			ExpectLocation: geom.Coord{1, 1},
+		},
+		{
+			Name:           "multi line string",
+			Geom:           geom.NewMultiLineString(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 1}}, {{5, 5}}}),
+			ExpectReason:   ReasonTooFewPoints,
+			ExpectLocation: geom.Coord{5, 5},
+		},
+		{
+			Name: "polygon",
+			Geom: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{11, 11}, {11, 15}, {15, 15}, {15, 11}, {11, 11}}}),
+		},
+		{
+			Name: "polygon with repeated points",
+			Geom: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{11, 11}, {11, 15}, {11, 15}, {15, 15}, {15, 11}, {11, 11}}}),
+		},
+		{
+			Name: "polygon with hole touching shell",
+			Geom: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+				{{0, 5}, {5, 2}, {5, 8}, {0, 5}},
+			}),
+		},
+		{
+			Name:           "collinear ring",
+			Geom:           geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{42, 42}, {1, 1}, {2, 2}, {42, 42}}}),
+			ExpectReason:   ReasonSelfIntersection,
+			ExpectLocation: geom.Coord{1, 1},
+		},
+		{
+			Name:           "unclosed ring",
+			Geom:           geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}),
+			ExpectReason:   ReasonRingNotClosed,
+			ExpectLocation: geom.Coord{0, 0},
+		},
+		{
+			Name:           "too few points",
+			Geom:           geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {10, 0}, {0, 0}}}),
+			ExpectReason:   ReasonTooFewPoints,
+			ExpectLocation: geom.Coord{0, 0},
+		},
+		{
+			Name:           "too few distinct points",
+			Geom:           geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {10, 0}, {10, 0}, {0, 0}}}),
+			ExpectReason:   ReasonTooFewPoints,
+			ExpectLocation: geom.Coord{0, 0},
+		},
+		{
+			Name:           "bow-tie",
+			Geom:           geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 1}, {1, 0}, {0, 1}, {0, 0}}}),
+			ExpectReason:   ReasonSelfIntersection,
+			ExpectLocation: geom.Coord{0.5, 0.5},
+		},
+		{
+			Name:           "ring self-touch",
+			Geom:           geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {10, 0}, {5, 5}, {10, 10}, {0, 10}, {5, 5}, {0, 0}}}),
+			ExpectReason:   ReasonRingSelfIntersection,
+			ExpectLocation: geom.Coord{5, 5},
+		},
+		{
+			Name: "hole crossing shell",
+			Geom: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+				{{5, 5}, {15, 5}, {15, 6}, {5, 6}, {5, 5}},
+			}),
+			ExpectReason:   ReasonSelfIntersection,
+			ExpectLocation: geom.Coord{10, 5},
+		},
+		{
+			Name: "hole outside shell",
+			Geom: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+				{{20, 20}, {21, 20}, {21, 21}, {20, 20}},
+			}),
+			ExpectReason:   ReasonHoleOutsideShell,
+			ExpectLocation: geom.Coord{20, 20},
+		},
+		{
+			Name: "nested holes",
+			Geom: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+				{{1, 1}, {9, 1}, {9, 9}, {1, 9}, {1, 1}},
+				{{2, 2}, {3, 2}, {3, 3}, {2, 2}},
+			}),
+			ExpectReason:   ReasonNestedHoles,
+			ExpectLocation: geom.Coord{2, 2},
+		},
+		{
+			Name: "disconnected interior",
+			Geom: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+				{{5, 0}, {10, 5}, {5, 10}, {0, 5}, {5, 0}},
+			}),
+			ExpectReason: ReasonDisconnectedInterior,
+		},
+		{
+			Name: "multi polygon touching at point",
+			Geom: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}},
+				{{{1, 1}, {2, 1}, {2, 2}, {1, 2}, {1, 1}}},
+			}),
+		},
+		{
+			Name: "multi polygon with island in hole",
+			Geom: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}, {{2, 2}, {8, 2}, {8, 8}, {2, 8}, {2, 2}}},
+				{{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}}},
+			}),
+		},
+		{
+			Name: "multi polygon sharing edge",
+			Geom: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}},
+				{{{1, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 0}}},
+			}),
+			ExpectReason:   ReasonSelfIntersection,
+			ExpectLocation: geom.Coord{1, 0},
+		},
+		{
+			Name: "multi polygon nested shells",
+			Geom: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+				{{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}}},
+			}),
+			ExpectReason:   ReasonNestedShells,
+			ExpectLocation: geom.Coord{4, 4},
+		},
+		{
+			Name: "geometry collection",
+			Geom: geom.NewGeometryCollection().MustPush(
+				geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{42, 42}),
+				geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 1}, {1, 0}, {0, 1}, {0, 0}}}),
+			),
+			ExpectReason:   ReasonSelfIntersection,
+			ExpectLocation: geom.Coord{0.5, 0.5},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			g := New(test.Geom)
+			err := g.Validate()
+
+			if test.ExpectReason == "" {
+				require.NoError(t, err)
+				assert.True(t, g.IsValid())
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidGeometry)
+			assert.False(t, g.IsValid())
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, test.ExpectReason, validationErr.Reason)
+
+			if test.ExpectLocation != nil {
+				assert.Equal(t, fmt.Sprint(test.ExpectLocation), fmt.Sprint(validationErr.Location))
+			}
+		})
+	}
+}
+
+func TestValidationErrorError(t *testing.T) {
+	err := &ValidationError{Reason: ReasonSelfIntersection, Location: geom.Coord{0.5, 0.5}}
+	assert.Equal(t, "invalid geometry: Self-intersection [0.5 0.5]", err.Error())
+
+	err = &ValidationError{Reason: ReasonTooFewPoints}
+	assert.Equal(t, "invalid geometry: Too few points", err.Error())
+}
+
+func TestGeometryValueValidateOnWrite(t *testing.T) {
+	bowTie := New(geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 1}, {1, 0}, {0, 1}, {0, 0}}}))
+
+	_, err := bowTie.Value()
+	require.NoError(t, err)
+
+	ValidateOnWrite = true
+	defer func() { ValidateOnWrite = false }()
+
+	_, err = bowTie.Value()
+	require.ErrorIs(t, err, ErrInvalidGeometry)
+}
@@ -0,0 +1,35 @@
+package georm
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/twpayne/go-geom/encoding/wkt"
+)
+
+// UnmarshalWKT decodes geometry from WKT or EWKT ("SRID=4326;POINT(...)")
+// with the given axis order of coordinates, AxisYX swaps them into lon, lat.
+// WKT without SRID gets the default SRID
+func (g *Geometry[T]) UnmarshalWKT(text string, order AxisOrder) error {
+	srid := SRID
+
+	if prefix, rest, ok := strings.Cut(text, ";"); ok && strings.HasPrefix(strings.ToUpper(strings.TrimSpace(prefix)), "SRID=") {
+		value, err := strconv.Atoi(strings.TrimSpace(prefix)[len("SRID="):])
+		if err != nil {
+			return err
+		}
+
+		srid, text = value, rest
+	}
+
+	if err := checkBytes(len(text)); err != nil {
+		return err
+	}
+
+	geometryT, err := wkt.Unmarshal(text)
+	if err != nil {
+		return err
+	}
+
+	return g.decoded(setSRID(geometryT, srid), order)
+}